@@ -27,6 +27,7 @@ import (
 var (
 	ErrPrivateExtension      = errors.New("private extension cannot be processed")
 	ErrExperimentalExtension = errors.New("experimental extension cannot be processed")
+	ErrInvalidDateTimeExt    = errors.New("input does not match the IXDTF date-time-ext grammar")
 )
 
 type Abnf struct {
@@ -40,10 +41,12 @@ func newAbnf(pattern string) *Abnf {
 var (
 	errInvalidExtensionFormat = errors.New("invalid extension format")
 	errUnknownDateTimeExt     = errors.New("unknown ABNF for date-time extension")
+	errUnknownOffset          = errors.New("unknown ABNF for numeric offset")
+	errUnknownPattern         = errors.New("unknown ABNF pattern")
 	errUnknownSuffixKey       = errors.New("unknown ABNF for suffix key")
 	errUnknownSuffixValues    = errors.New("unknown ABNF for suffix values")
-	errUnknownTimezone        = errors.New("unknown ABNF for timezone name")
-	errUnknownTimezoneTag     = errors.New("unknown ABNF for timezone tag")
+	errUnknownTimezone        = errors.New("unknown ABNF for timezone annotation")
+	errUnknownTimezoneName    = errors.New("unknown ABNF for timezone name")
 )
 
 func (a *Abnf) ensure(expected *Abnf, err error) error {
@@ -66,11 +69,17 @@ func (a *Abnf) ensurePattern(input string) error {
 //
 //nolint:gochecknoglobals // ABNF patterns are constants used for validation
 var (
-	AbnfTimezone    = newAbnf(`^[A-Za-z_][A-Za-z._0-9+-]*(/[A-Za-z_][A-Za-z._0-9+-]*)*$`)
-	AbnfTimezoneTag = newAbnf(
+	// AbnfTimezoneName matches a bare time-zone name such as "Asia/Tokyo".
+	AbnfTimezoneName = newAbnf(`^[A-Za-z_][A-Za-z._0-9+-]*(/[A-Za-z_][A-Za-z._0-9+-]*)*$`)
+	// AbnfTimezone matches a bracketed time-zone annotation with an optional
+	// critical flag: a name such as "[!Asia/Tokyo]" or an offset such as
+	// "[+09:00]".
+	AbnfTimezone = newAbnf(
 		`^\[!?[A-Za-z_][A-Za-z._0-9+-]*(/[A-Za-z_][A-Za-z._0-9+-]*)*\]$|^\[!?[+-][0-9]{2}:[0-9]{2}\]$`,
 	)
 
+	AbnfOffset = newAbnf(`^[+-][0-9]{2}:[0-9]{2}$`)
+
 	AbnfSuffixKey    = newAbnf(`^[a-z_][a-z_0-9-]*$`)
 	AbnfSuffixValues = newAbnf(`^[A-Za-z0-9]+(?:-[A-Za-z0-9]+)*$`)
 
@@ -79,10 +88,73 @@ var (
 	)
 )
 
+// AbnfTimezoneTag is the former name of AbnfTimezone, the bracketed
+// annotation rule.
+//
+// Deprecated: use AbnfTimezone.
+//
+//nolint:gochecknoglobals // Alias of the AbnfTimezone pattern variable.
+var AbnfTimezoneTag = AbnfTimezone
+
+// Alternative "TimeZone" spellings of the pattern names. The package
+// spells the term "Timezone" throughout; these aliases refer to the same
+// patterns, so the per-pattern methods accept them.
+//
+// Deprecated: use AbnfTimezone.
+//
+//nolint:gochecknoglobals // Aliases of the pattern variables above.
+var (
+	AbnfTimeZone    = AbnfTimezone
+	AbnfTimeZoneTag = AbnfTimezone
+)
+
+// IsTimeZoneSyntax is an alias of IsTimezoneSyntax.
+//
+// Deprecated: use IsTimezoneSyntax.
+func IsTimeZoneSyntax(input string) bool {
+	return IsTimezoneSyntax(input)
+}
+
 // IsTimezoneSyntax returns true if the input matches the lexical pattern of a timezone name.
 // (ABNF for tz name) without performing existence (time.LoadLocation) validation.
 func IsTimezoneSyntax(input string) bool {
-	return AbnfTimezone.regexp.MatchString(input)
+	return AbnfTimezoneName.regexp.MatchString(input)
+}
+
+// Validate validates input against the rule a stands for by dispatching to
+// its specialized method, e.g. ValidateSuffixKey for AbnfSuffixKey. Time-zone
+// patterns are checked syntactically only, as with strict=false; call
+// ValidateTimezoneName or ValidateTimezone directly to also load the zone.
+func (a *Abnf) Validate(input string) error {
+	switch a {
+	case AbnfTimezoneName:
+		return a.ValidateTimezoneName(input, false)
+	case AbnfTimezone:
+		return a.ValidateTimezone(input, false)
+	case AbnfOffset:
+		return a.ValidateOffset(input)
+	case AbnfSuffixKey:
+		return a.ValidateSuffixKey(input)
+	case AbnfSuffixValues:
+		return a.ValidateSuffixValues(input)
+	case AbnfDateTimeExt:
+		return a.ValidateDateTimeExt(input)
+	default:
+		return errUnknownPattern
+	}
+}
+
+// ValidateFull validates a complete IXDTF string, such as
+// "2025-01-02T03:04:05+09:00[Asia/Tokyo][u-ca=japanese]", against the
+// combined RFC 9557 date-time-ext grammar of AbnfDateTimeExt, returning
+// ErrInvalidDateTimeExt on a mismatch. Only syntax is checked: time-zone
+// existence, offset consistency, and the handling of private or
+// experimental keys are left to the ixdtf package.
+func ValidateFull(input string) error {
+	if AbnfDateTimeExt.ValidateDateTimeExt(input) != nil {
+		return ErrInvalidDateTimeExt
+	}
+	return nil
 }
 
 // ValidateDateTimeExt validates a date-time string with extensions according to the ABNF and additional rules.
@@ -93,6 +165,16 @@ func (a *Abnf) ValidateDateTimeExt(input string) error {
 	return a.ensurePattern(input)
 }
 
+// ValidateOffset validates a numeric offset in the RFC 3339 time-numoffset
+// form, such as "+09:00", without brackets. Hour and minute ranges are not
+// checked.
+func (a *Abnf) ValidateOffset(input string) error {
+	if err := a.ensure(AbnfOffset, errUnknownOffset); err != nil {
+		return err
+	}
+	return a.ensurePattern(input)
+}
+
 // ValidateSuffixKey validates a suffix key according to the ABNF and additional rules.
 func (a *Abnf) ValidateSuffixKey(input string) error {
 	if err := a.ensure(AbnfSuffixKey, errUnknownSuffixKey); err != nil {
@@ -112,27 +194,54 @@ func (a *Abnf) ValidateSuffixValues(input string) error {
 	return a.ensurePattern(input)
 }
 
-func (a *Abnf) ValidateTimezone(input string, strict bool) error {
-	if err := a.ensure(AbnfTimezone, errUnknownTimezone); err != nil {
+// ValidateTimezoneName validates a bare time-zone name such as
+// "Asia/Tokyo". In strict mode the zone must also load with
+// time.LoadLocation.
+func (a *Abnf) ValidateTimezoneName(input string, strict bool) error {
+	if err := a.ensure(AbnfTimezoneName, errUnknownTimezoneName); err != nil {
 		return err
 	}
 	if err := a.ensurePattern(input); err != nil {
 		return err
 	}
-	return validateTimezone(input, strict)
+	return validateTimezoneName(input, strict)
 }
 
-func (a *Abnf) ValidateTimezoneTag(input string, strict bool) error {
-	if err := a.ensure(AbnfTimezoneTag, errUnknownTimezoneTag); err != nil {
+// ValidateTimezone validates a bracketed time-zone annotation such as
+// "[!Asia/Tokyo]" or "[+09:00]". In strict mode a named zone must also load
+// with time.LoadLocation.
+func (a *Abnf) ValidateTimezone(input string, strict bool) error {
+	if err := a.ensure(AbnfTimezone, errUnknownTimezone); err != nil {
 		return err
 	}
 	if err := a.ensurePattern(input); err != nil {
 		return err
 	}
-	return validateTimezoneTag(input, strict)
+	return validateTimezone(input, strict)
 }
 
-func validateTimezone(name string, strict bool) error {
+// ValidateTimezoneTag is the former name of ValidateTimezone.
+//
+// Deprecated: use ValidateTimezone.
+func (a *Abnf) ValidateTimezoneTag(input string, strict bool) error {
+	return a.ValidateTimezone(input, strict)
+}
+
+// ValidateTimeZone is an alias of ValidateTimezone.
+//
+// Deprecated: use ValidateTimezone.
+func (a *Abnf) ValidateTimeZone(input string, strict bool) error {
+	return a.ValidateTimezone(input, strict)
+}
+
+// ValidateTimeZoneTag is an alias of ValidateTimezoneTag.
+//
+// Deprecated: use ValidateTimezone.
+func (a *Abnf) ValidateTimeZoneTag(input string, strict bool) error {
+	return a.ValidateTimezoneTag(input, strict)
+}
+
+func validateTimezoneName(name string, strict bool) error {
 	if !strict {
 		// In non-strict mode, skip time.LoadLocation validation
 		return nil
@@ -141,7 +250,7 @@ func validateTimezone(name string, strict bool) error {
 	return err
 }
 
-func validateTimezoneTag(name string, strict bool) error {
+func validateTimezone(name string, strict bool) error {
 	if !strict {
 		// In non-strict mode, skip time.LoadLocation validation
 		return nil
@@ -1,6 +1,7 @@
 package abnf_test
 
 import (
+	"errors"
 	"sort"
 	"testing"
 
@@ -102,6 +103,86 @@ func TestAbnf_ValidateDateTimeExt(t *testing.T) {
 	}
 }
 
+func TestAbnf_ValidateOffset(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		pat      *abnf.Abnf
+		valids   []string
+		invalids []string
+	}{
+		{
+			name: "Offset",
+			pat:  abnf.AbnfOffset,
+			valids: []string{
+				"+00:00",
+				"+09:00",
+				"-03:30",
+				"-00:00",
+			},
+			invalids: []string{
+				"",
+				"+0900",
+				"+9:00",
+				"09:00",
+				"[+09:00]",
+				"+09:00:00",
+				"Z",
+			},
+		},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			for _, v := range tc.valids {
+				if err := tc.pat.ValidateOffset(v); err != nil {
+					t.Errorf("expected valid %q for %s: %v", v, tc.name, err)
+				}
+			}
+			for _, iv := range tc.invalids {
+				if err := tc.pat.ValidateOffset(iv); err == nil {
+					t.Errorf("expected invalid %q for %s", iv, tc.name)
+				}
+			}
+
+			if err := abnf.AbnfSuffixKey.ValidateOffset("+09:00"); err == nil {
+				t.Error("expected error for mismatched ABNF type in ValidateOffset")
+			}
+		})
+	}
+}
+
+func TestValidateFull(t *testing.T) {
+	t.Parallel()
+
+	valids := []string{
+		"2025-01-02T03:04:05Z",
+		"2025-01-02T03:04:05.123+09:00[Asia/Tokyo][!u-ca=japanese]",
+		"2025-01-02T03:04:05-00:00[!+09:00][t-a=1-b2]",
+	}
+	invalids := []string{
+		"",
+		"2025-01-02",
+		"2025-01-02 03:04:05Z",
+		"2025-01-02T03:04:05Z[U-CA=japanese]",
+		"2025-01-02T03:04:05Z[u-ca=]",
+		"2025-01-02T03:04:05Z[Asia/Tokyo",
+	}
+	for _, v := range valids {
+		if err := abnf.ValidateFull(v); err != nil {
+			t.Errorf("ValidateFull(%q) unexpected error: %v", v, err)
+		}
+	}
+	for _, iv := range invalids {
+		if err := abnf.ValidateFull(iv); !errors.Is(err, abnf.ErrInvalidDateTimeExt) {
+			t.Errorf("ValidateFull(%q) error = %v, want %v", iv, err, abnf.ErrInvalidDateTimeExt)
+		}
+	}
+}
+
 func TestAbnf_ValidateSuffixKey(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -192,7 +273,7 @@ func TestAbnf_ValidateSuffixValues(t *testing.T) {
 	}
 }
 
-func TestAbnf_ValidateTimezone(t *testing.T) {
+func TestAbnf_ValidateTimezoneName(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
 		name     string
@@ -203,7 +284,7 @@ func TestAbnf_ValidateTimezone(t *testing.T) {
 	}{
 		{
 			name:   "non-strict mode",
-			pat:    abnf.AbnfTimezone,
+			pat:    abnf.AbnfTimezoneName,
 			strict: false,
 			valids: []string{
 				"A/B",
@@ -223,7 +304,7 @@ func TestAbnf_ValidateTimezone(t *testing.T) {
 		},
 		{
 			name:   "strict mode",
-			pat:    abnf.AbnfTimezone,
+			pat:    abnf.AbnfTimezoneName,
 			strict: true,
 			valids: []string{
 				"America/New_York",
@@ -257,24 +338,24 @@ func TestAbnf_ValidateTimezone(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			for _, v := range tc.valids {
-				if err := tc.pat.ValidateTimezone(v, tc.strict); err != nil {
+				if err := tc.pat.ValidateTimezoneName(v, tc.strict); err != nil {
 					t.Errorf("expected valid %q for %s: %v", v, tc.name, err)
 				}
 			}
 			for _, iv := range tc.invalids {
-				if err := tc.pat.ValidateTimezone(iv, tc.strict); err == nil {
+				if err := tc.pat.ValidateTimezoneName(iv, tc.strict); err == nil {
 					t.Errorf("expected invalid %q for %s", iv, tc.name)
 				}
 			}
 
-			if err := abnf.AbnfSuffixKey.ValidateTimezone("Asia/Tokyo", tc.strict); err == nil {
-				t.Error("expected error for mismatched ABNF type in ValidateTimezone")
+			if err := abnf.AbnfSuffixKey.ValidateTimezoneName("Asia/Tokyo", tc.strict); err == nil {
+				t.Error("expected error for mismatched ABNF type in ValidateTimezoneName")
 			}
 		})
 	}
 }
 
-func TestAbnf_ValidateTimezoneTag(t *testing.T) {
+func TestAbnf_ValidateTimezone(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
 		name     string
@@ -284,15 +365,15 @@ func TestAbnf_ValidateTimezoneTag(t *testing.T) {
 		invalids []string
 	}{
 		{
-			name:     "TimezoneTag non-strict",
-			pat:      abnf.AbnfTimezoneTag,
+			name:     "non-strict mode",
+			pat:      abnf.AbnfTimezone,
 			strict:   false,
 			valids:   []string{"[!America/New_York]", "[-05:00]", "[+09:00]", "[Asia/Tokyo]", "[UTC]"},
 			invalids: []string{"", "[=value]", "[TAG=VALUE]", "invalid", "[tag@value]", "[tag=]", "u-ca=gregorian"},
 		},
 		{
-			name:   "TimezoneTag strict",
-			pat:    abnf.AbnfTimezoneTag,
+			name:   "strict mode",
+			pat:    abnf.AbnfTimezone,
 			strict: true,
 			valids: []string{"[!America/New_York]", "[-05:00]", "[+09:00]", "[Asia/Tokyo]", "[UTC]"},
 			invalids: []string{
@@ -314,19 +395,85 @@ func TestAbnf_ValidateTimezoneTag(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			for _, v := range tc.valids {
-				if err := tc.pat.ValidateTimezoneTag(v, tc.strict); err != nil {
+				if err := tc.pat.ValidateTimezone(v, tc.strict); err != nil {
 					t.Errorf("expected valid %q for %s: %v", v, tc.name, err)
 				}
 			}
 			for _, iv := range tc.invalids {
-				if err := tc.pat.ValidateTimezoneTag(iv, tc.strict); err == nil {
+				if err := tc.pat.ValidateTimezone(iv, tc.strict); err == nil {
 					t.Errorf("expected invalid %q for %s", iv, tc.name)
 				}
 			}
 
-			if err := abnf.AbnfSuffixKey.ValidateTimezoneTag("[Asia/Tokyo]", tc.strict); err == nil {
-				t.Error("expected error for mismatched ABNF type in ValidateTimezoneTag")
+			if err := abnf.AbnfSuffixKey.ValidateTimezone("[Asia/Tokyo]", tc.strict); err == nil {
+				t.Error("expected error for mismatched ABNF type in ValidateTimezone")
+			}
+		})
+	}
+}
+
+// TestAbnf_TimeZoneAliases verifies that the deprecated "TimeZone" spellings
+// and the former "TimezoneTag" names behave like their canonical
+// counterparts.
+//
+//nolint:staticcheck // The deprecated aliases are what this test covers.
+func TestAbnf_TimeZoneAliases(t *testing.T) {
+	t.Parallel()
+
+	if abnf.AbnfTimeZone != abnf.AbnfTimezone || abnf.AbnfTimeZoneTag != abnf.AbnfTimezone ||
+		abnf.AbnfTimezoneTag != abnf.AbnfTimezone {
+		t.Fatal("aliases do not refer to the canonical patterns")
+	}
+	for _, input := range []string{"Asia/Tokyo", "Asia//Tokyo"} {
+		if got, want := abnf.IsTimeZoneSyntax(input), abnf.IsTimezoneSyntax(input); got != want {
+			t.Errorf("IsTimeZoneSyntax(%q) = %t, want %t", input, got, want)
+		}
+	}
+	for _, input := range []string{"[!Asia/Tokyo]", "[+09:00]", "Asia/Tokyo"} {
+		want := abnf.AbnfTimezone.ValidateTimezone(input, false)
+		for name, got := range map[string]error{
+			"ValidateTimeZone":    abnf.AbnfTimeZone.ValidateTimeZone(input, false),
+			"ValidateTimeZoneTag": abnf.AbnfTimeZoneTag.ValidateTimeZoneTag(input, false),
+			"ValidateTimezoneTag": abnf.AbnfTimezoneTag.ValidateTimezoneTag(input, false),
+		} {
+			if (got == nil) != (want == nil) {
+				t.Errorf("%s(%q) = %v, want %v", name, input, got, want)
+			}
+		}
+	}
+}
+
+func TestAbnf_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		pat     *abnf.Abnf
+		valid   string
+		invalid string
+	}{
+		{"DateTimeExt", abnf.AbnfDateTimeExt, "2025-01-02T03:04:05Z[u-ca=japanese]", "2025-01-02T03:04:05Z[u-ca=]"},
+		{"Offset", abnf.AbnfOffset, "+09:00", "+0900"},
+		{"SuffixKey", abnf.AbnfSuffixKey, "u-ca", "x-private"},
+		{"SuffixValues", abnf.AbnfSuffixValues, "japanese", "-japanese"},
+		{"Timezone", abnf.AbnfTimezone, "[!+09:00]", "Asia/Tokyo"},
+		{"TimezoneName", abnf.AbnfTimezoneName, "No/Such_Zone", "[Etc/GMT+9]"},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tc.pat.Validate(tc.valid); err != nil {
+				t.Errorf("expected valid %q for %s: %v", tc.valid, tc.name, err)
+			}
+			if err := tc.pat.Validate(tc.invalid); err == nil {
+				t.Errorf("expected invalid %q for %s", tc.invalid, tc.name)
 			}
 		})
 	}
+
+	if err := (&abnf.Abnf{}).Validate("value"); err == nil {
+		t.Error("expected error for an unknown ABNF pattern in Validate")
+	}
 }
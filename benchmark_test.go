@@ -2,6 +2,7 @@ package ixdtf_test
 
 import (
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -86,6 +87,7 @@ func BenchmarkParse(b *testing.B) {
 		strict      bool
 	}{
 		{"rfc3339", "2025-01-02T03:04:05Z", false},
+		{"rfc3339_milli", "2025-01-02T03:04:05.123Z", false},
 		{"rfc3339_nano", "2025-01-02T03:04:05.123456789Z", false},
 		{"extended_tz", "2025-06-07T08:09:10+09:00[Asia/Tokyo]", false},
 		{"extended_tz_tags", "2025-06-07T08:09:10+01:00[Europe/Paris][u-ca=gregory]", false},
@@ -133,3 +135,73 @@ func BenchmarkValidate(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkValidate_ManyBracketGroups measures rejection of a pathological
+// suffix; the bound check must fail it before the ABNF pattern runs.
+func BenchmarkValidate_ManyBracketGroups(b *testing.B) {
+	input := "2025-01-01T00:00:00Z" + strings.Repeat("[a=b]", 10*ixdtf.DefaultMaxSuffixElements)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = ixdtf.Validate(input, false)
+	}
+}
+
+// BenchmarkFormatNano_FullExtensions formats a timestamp carrying a zone and
+// several tags from many goroutines at once, exercising the shared format
+// buffer pool under contention.
+func BenchmarkFormatNano_FullExtensions(b *testing.B) {
+	paris := time.FixedZone("Europe/Paris", 1*3600)
+	t := benchmarkTime().In(paris)
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Location: paris,
+		Tags: map[string]string{
+			"u-ca":     "gregory",
+			"t-format": "iso",
+			"a-key":    "first-value",
+			"b-key":    "second-value",
+		},
+		Critical: map[string]bool{"u-ca": true},
+	})
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = ixdtf.FormatNano(t, ext)
+		}
+	})
+}
+
+// BenchmarkFormat_LoadedLocation formats with a location from
+// time.LoadLocation, the usual caller case, so the zone check in the format
+// path resolves a real zone rather than a FixedZone placeholder.
+func BenchmarkFormat_LoadedLocation(b *testing.B) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		b.Skipf("zoneinfo unavailable: %v", err)
+	}
+	t := benchmarkTime().In(tokyo)
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Location: tokyo})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = ixdtf.Format(t, ext)
+	}
+}
+
+// BenchmarkFormat_PlaceholderLocation is the counterpart of
+// BenchmarkFormat_LoadedLocation for a FixedZone placeholder, which still
+// resolves through the zone cache; the gap between the two is that probe.
+func BenchmarkFormat_PlaceholderLocation(b *testing.B) {
+	if err := ixdtf.PreloadTimezones("Asia/Tokyo"); err != nil {
+		b.Skipf("zoneinfo unavailable: %v", err)
+	}
+	placeholder := time.FixedZone("Asia/Tokyo", 9*60*60)
+	t := benchmarkTime().In(placeholder)
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Location: placeholder})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = ixdtf.Format(t, ext)
+	}
+}
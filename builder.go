@@ -0,0 +1,81 @@
+package ixdtf
+
+import (
+	"maps"
+	"time"
+)
+
+// Builder assembles IXDTFExtensions incrementally, for call sites that add
+// tags conditionally. Methods return the Builder for chaining. The first
+// invalid input is remembered and reported by Build; later calls are then
+// ignored.
+type Builder struct {
+	ext *IXDTFExtensions
+	err error
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{ext: NewIXDTFExtensions(nil)}
+}
+
+// Location sets the time-zone annotation.
+func (b *Builder) Location(loc *time.Location) *Builder {
+	if b.err == nil {
+		b.ext.Location = loc
+	}
+	return b
+}
+
+// CriticalLocation marks the time-zone annotation as critical ("!").
+func (b *Builder) CriticalLocation() *Builder {
+	if b.err == nil {
+		b.ext.CriticalLocation = true
+	}
+	return b
+}
+
+// Tag adds the suffix tag key=value. The key must match the suffix-key
+// grammar and the value the suffix-values grammar (RFC 9557 Section 4.1);
+// an invalid value is reported as ErrInvalidExtension.
+// Adding a key again replaces its value and keeps its critical flag.
+func (b *Builder) Tag(key, value string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if err := validateTag(key, value); err != nil {
+		b.err = err
+		return b
+	}
+	b.ext.Tags[key] = value
+	return b
+}
+
+// Critical marks the tag key as critical ("!"). The tag itself may be added
+// before or after; Build fails when it is never added.
+func (b *Builder) Critical(key string) *Builder {
+	if b.err == nil {
+		b.ext.Critical[key] = true
+	}
+	return b
+}
+
+// Build returns the assembled extensions, or the first error recorded by a
+// builder method. The result is also checked the way Format checks its
+// input, so a value that Build accepts formats without an extension error.
+// Each call returns a fresh copy, so the Builder can keep being used.
+func (b *Builder) Build() (*IXDTFExtensions, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := validateExtensionsStrict(b.ext, true, stdLocationLoader{}); err != nil {
+		return nil, err
+	}
+	ext := NewIXDTFExtensions(&NewIXDTFExtensionsArgs{
+		Location:         b.ext.Location,
+		CriticalLocation: b.ext.CriticalLocation,
+	})
+	maps.Copy(ext.Tags, b.ext.Tags)
+	maps.Copy(ext.Critical, b.ext.Critical)
+	return ext, nil
+}
@@ -0,0 +1,86 @@
+package ixdtf_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+
+	t.Run("chaining", func(t *testing.T) {
+		t.Parallel()
+		ext, err := ixdtf.NewBuilder().
+			Location(tokyo).
+			CriticalLocation().
+			Tag("u-ca", "gregory").
+			Critical("u-ca").
+			Tag("t-format", "iso").
+			Build()
+		if err != nil {
+			t.Fatalf("Build() unexpected error: %v", err)
+		}
+		want := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+			Location:         tokyo,
+			CriticalLocation: true,
+			Tags:             map[string]string{"u-ca": "gregory", "t-format": "iso"},
+			Critical:         map[string]bool{"u-ca": true},
+		})
+		if !extensionsEqual(ext, want) {
+			t.Errorf("Build() = %+v, want %+v", ext, want)
+		}
+	})
+
+	t.Run("duplicate key replaces the value", func(t *testing.T) {
+		t.Parallel()
+		ext, err := ixdtf.NewBuilder().Tag("u-ca", "hebrew").Critical("u-ca").Tag("u-ca", "gregory").Build()
+		if err != nil {
+			t.Fatalf("Build() unexpected error: %v", err)
+		}
+		if ext.Tags["u-ca"] != "gregory" || !ext.Critical["u-ca"] {
+			t.Errorf("Build() = %+v, want critical u-ca=gregory", ext)
+		}
+	})
+
+	t.Run("builds independent copies", func(t *testing.T) {
+		t.Parallel()
+		b := ixdtf.NewBuilder().Tag("a-key", "1")
+		first, _ := b.Build()
+		second, err := b.Tag("b-key", "2").Build()
+		if err != nil {
+			t.Fatalf("Build() unexpected error: %v", err)
+		}
+		if len(first.Tags) != 1 || len(second.Tags) != 2 {
+			t.Errorf("Build() tags = %v then %v, want 1 then 2 entries", first.Tags, second.Tags)
+		}
+	})
+
+	errorTests := []struct {
+		name    string
+		builder *ixdtf.Builder
+		wantErr error
+	}{
+		{"invalid key", ixdtf.NewBuilder().Tag("U-CA", "gregory"), nil},
+		{"empty value", ixdtf.NewBuilder().Tag("u-ca", ""), ixdtf.ErrInvalidExtension},
+		{"invalid value", ixdtf.NewBuilder().Tag("a-key", "bad--value"), ixdtf.ErrInvalidExtension},
+		{"unknown calendar", ixdtf.NewBuilder().Tag("u-ca", "hoge"), ixdtf.ErrInvalidTagCalendarIdentifier},
+		{"critical without tag", ixdtf.NewBuilder().Critical("u-ca"), ixdtf.ErrCriticalExtension},
+		{"first error is kept", ixdtf.NewBuilder().Tag("a-key", "").Tag("U-CA", "x"), ixdtf.ErrInvalidExtension},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ext, err := tt.builder.Build()
+			if err == nil {
+				t.Fatalf("Build() = %+v, want error", ext)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Build() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package ixdtf
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 // ExtensionUnicodeCalendar is tag key for Unicode calendar extension.
 // https://www.rfc-editor.org/rfc/rfc9557.html#section-5
 const ExtensionUnicodeCalendar = "u-ca"
 
+// ExtensionUnicodeNumberingSystem is tag key for Unicode numbering system extension.
+// https://www.rfc-editor.org/rfc/rfc9557.html#section-5
+const ExtensionUnicodeNumberingSystem = "u-nu"
+
 // validateTagValue enforces value rules for registered suffix keys
 // (RFC 9557 Section 5). Unregistered keys have no value constraints.
 func validateTagValue(key, value string) error {
@@ -46,3 +53,35 @@ func isUnicodeCalendarIdentifier(value string) bool {
 		return false
 	}
 }
+
+// NumberingSystems returns the Unicode numbering-system identifiers (CLDR
+// numberingSystems.xml) accepted for ExtensionUnicodeNumberingSystem when
+// WithValidateNumberingSystem is set, in sorted order. The returned slice is
+// a copy and may be modified.
+func NumberingSystems() []string {
+	return slices.Clone(numberingSystems)
+}
+
+// IsNumberingSystem reports whether value is a known Unicode numbering-system
+// identifier, ignoring ASCII case as calendar identifiers do.
+func IsNumberingSystem(value string) bool {
+	_, found := slices.BinarySearch(numberingSystems, strings.ToLower(value))
+	return found
+}
+
+// numberingSystems is kept sorted for IsNumberingSystem's binary search.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var numberingSystems = []string{
+	"adlm", "ahom", "arab", "arabext", "armn", "armnlow", "bali", "beng",
+	"bhks", "brah", "cakm", "cham", "cyrl", "deva", "diak", "ethi",
+	"fullwide", "geor", "gong", "gonm", "grek", "greklow", "gujr", "guru",
+	"hanidays", "hanidec", "hans", "hansfin", "hant", "hantfin", "hebr", "hmng",
+	"hmnp", "java", "jpan", "jpanfin", "jpanyear", "kali", "kawi", "khmr",
+	"knda", "lana", "lanatham", "laoo", "latn", "lepc", "limb", "mathbold",
+	"mathdbl", "mathmono", "mathsanb", "mathsans", "mlym", "modi", "mong", "mroo",
+	"mtei", "mymr", "mymrshan", "mymrtlng", "nagm", "newa", "nkoo", "olck",
+	"orya", "osma", "rohg", "roman", "romanlow", "saur", "segment", "shrd",
+	"sind", "sinh", "sora", "sund", "takr", "talu", "taml", "tamldec",
+	"telu", "thai", "tibt", "tirh", "tnsa", "vaii", "wara", "wcho",
+}
@@ -1,6 +1,8 @@
 package ixdtf_test
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/8beeeaaat/ixdtf"
@@ -43,3 +45,63 @@ func TestValidateUnicodeCalendarIdentifiers(t *testing.T) {
 		})
 	}
 }
+
+func TestWithValidateNumberingSystem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		opts    []ixdtf.ParseOption
+		wantErr error
+	}{
+		{
+			name:  "known identifier",
+			input: "2025-03-04T05:06:07Z[u-nu=latn]",
+			opts:  []ixdtf.ParseOption{ixdtf.WithValidateNumberingSystem()},
+		},
+		{
+			name:  "identifier is case-insensitive",
+			input: "2025-03-04T05:06:07Z[!u-nu=HaniDec]",
+			opts:  []ixdtf.ParseOption{ixdtf.WithValidateNumberingSystem()},
+		},
+		{
+			name:    "typo is rejected",
+			input:   "2025-03-04T05:06:07Z[u-nu=latin]",
+			opts:    []ixdtf.ParseOption{ixdtf.WithValidateNumberingSystem()},
+			wantErr: ixdtf.ErrUnknownNumberingSystem,
+		},
+		{
+			name:  "typo passes without the option",
+			input: "2025-03-04T05:06:07Z[u-nu=latin]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, strict := range []bool{false, true} {
+				err := ixdtf.Validate(tt.input, strict, tt.opts...)
+				if tt.wantErr == nil && err != nil {
+					t.Fatalf("Validate(%q, %t) unexpected error: %v", tt.input, strict, err)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Validate(%q, %t) error = %v, want %v", tt.input, strict, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestNumberingSystems(t *testing.T) {
+	t.Parallel()
+
+	systems := ixdtf.NumberingSystems()
+	if !slices.IsSorted(systems) || !slices.Contains(systems, "jpan") {
+		t.Fatalf("NumberingSystems() = %v, want sorted list containing jpan", systems)
+	}
+	systems[0] = "mutated"
+	if !ixdtf.IsNumberingSystem(ixdtf.NumberingSystems()[0]) {
+		t.Error("mutating the NumberingSystems() result changed the package list")
+	}
+}
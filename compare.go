@@ -0,0 +1,72 @@
+package ixdtf
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Compare parses a and b in non-strict mode and compares their instants,
+// returning -1, 0, or +1 as a is before, equal to, or after b. Equal instants
+// are ordered by their suffixes (see SuffixOf), so the order is total and
+// stable across calls. The first parse error is returned, with a result of 0.
+func Compare(a, b string) (int, error) {
+	ta, _, err := Parse(a, false)
+	if err != nil {
+		return 0, err
+	}
+	tb, _, err := Parse(b, false)
+	if err != nil {
+		return 0, err
+	}
+	if c := ta.Compare(tb); c != 0 {
+		return c, nil
+	}
+	return strings.Compare(SuffixOf(a), SuffixOf(b)), nil
+}
+
+// SortStrings sorts ss in place into the order defined by Compare, parsing
+// each string once. If any string fails to parse, ss is left unchanged and
+// the first error is returned.
+func SortStrings(ss []string) error {
+	type entry struct {
+		s string
+		t time.Time
+	}
+	entries := make([]entry, len(ss))
+	for i, s := range ss {
+		t, _, err := Parse(s, false)
+		if err != nil {
+			return err
+		}
+		entries[i] = entry{s: s, t: t}
+	}
+	slices.SortStableFunc(entries, func(x, y entry) int {
+		if c := x.t.Compare(y.t); c != 0 {
+			return c
+		}
+		return strings.Compare(SuffixOf(x.s), SuffixOf(y.s))
+	})
+	for i, e := range entries {
+		ss[i] = e.s
+	}
+	return nil
+}
+
+// AddDuration parses s in non-strict mode, adds d to its instant, and formats
+// the result with the original extensions, as by FormatNano.
+//
+// The duration is absolute elapsed time, as with time.Time.Add. When the
+// annotated zone was applied to s (see Parse), the result is expressed in
+// that zone and its offset follows the zone's rules at the new instant, so
+// adding an hour to "2025-03-09T01:30:00-05:00[America/New_York]" across the
+// US spring-forward gives "2025-03-09T03:30:00-04:00[America/New_York]".
+// Otherwise, such as when the offset was inconsistent with the zone, the
+// original offset is kept unchanged.
+func AddDuration(s string, d time.Duration) (string, error) {
+	t, ext, err := Parse(s, false)
+	if err != nil {
+		return "", err
+	}
+	return FormatNano(t.Add(d), ext)
+}
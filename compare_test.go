@@ -0,0 +1,131 @@
+package ixdtf_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2025-01-01T00:00:00Z", "2025-01-01T00:00:01Z", -1},
+		{"2025-01-01T09:00:00+09:00[Asia/Tokyo]", "2025-01-01T00:00:00Z[Asia/Tokyo]", 0},
+		// Equal instants are ordered by suffix.
+		{"2025-01-01T09:00:00+09:00[Asia/Tokyo]", "2025-01-01T00:00:00Z", 1},
+		{"2025-01-01T00:00:00.5Z", "2025-01-01T00:00:00Z[u-ca=gregory]", 1},
+	}
+	for _, tt := range tests {
+		got, err := ixdtf.Compare(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Compare(%q, %q) unexpected error: %v", tt.a, tt.b, err)
+		}
+		if got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if back, _ := ixdtf.Compare(tt.b, tt.a); back != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, back, -tt.want)
+		}
+	}
+
+	if _, err := ixdtf.Compare("2025-01-01T00:00:00Z", "bogus"); err == nil {
+		t.Error("Compare() expected error for unparseable input")
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	t.Parallel()
+
+	ss := []string{
+		"2025-01-01T10:00:00+09:00[Asia/Tokyo]",
+		"2025-01-01T00:00:00Z[u-ca=gregory]",
+		"2024-12-31T20:00:00-05:00[America/New_York]",
+		"2025-01-01T00:00:00Z",
+	}
+	want := []string{
+		"2025-01-01T00:00:00Z",
+		"2025-01-01T00:00:00Z[u-ca=gregory]",
+		"2024-12-31T20:00:00-05:00[America/New_York]",
+		"2025-01-01T10:00:00+09:00[Asia/Tokyo]",
+	}
+	if err := ixdtf.SortStrings(ss); err != nil {
+		t.Fatalf("SortStrings() unexpected error: %v", err)
+	}
+	if !slices.Equal(ss, want) {
+		t.Errorf("SortStrings() = %v, want %v", ss, want)
+	}
+
+	bad := []string{"2025-01-02T00:00:00Z", "bogus", "2025-01-01T00:00:00Z"}
+	orig := slices.Clone(bad)
+	if err := ixdtf.SortStrings(bad); err == nil {
+		t.Error("SortStrings() expected error for unparseable input")
+	}
+	if !slices.Equal(bad, orig) {
+		t.Errorf("SortStrings() modified input on error: %v", bad)
+	}
+}
+
+func TestAddDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		d     time.Duration
+		want  string
+	}{
+		{
+			name:  "spring forward",
+			input: "2025-03-09T01:30:00-05:00[America/New_York]",
+			d:     time.Hour,
+			want:  "2025-03-09T03:30:00-04:00[America/New_York]",
+		},
+		{
+			name:  "fall back",
+			input: "2025-11-02T01:30:00-04:00[America/New_York][u-ca=gregory]",
+			d:     time.Hour,
+			want:  "2025-11-02T01:30:00-05:00[America/New_York][u-ca=gregory]",
+		},
+		{
+			name:  "unknown local offset takes the zone",
+			input: "2025-01-01T00:00:00Z[Asia/Tokyo]",
+			d:     90 * time.Minute,
+			want:  "2025-01-01T10:30:00+09:00[Asia/Tokyo]",
+		},
+		{
+			name:  "inconsistent offset is kept",
+			input: "2025-06-01T12:00:00+09:00[America/New_York]",
+			d:     -time.Second,
+			want:  "2025-06-01T11:59:59+09:00[America/New_York]",
+		},
+		{
+			name:  "no suffix",
+			input: "2025-01-01T00:00:00.25Z",
+			d:     time.Millisecond,
+			want:  "2025-01-01T00:00:00.251Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ixdtf.AddDuration(tt.input, tt.d)
+			if err != nil {
+				t.Fatalf("AddDuration(%q, %v) unexpected error: %v", tt.input, tt.d, err)
+			}
+			if got != tt.want {
+				t.Errorf("AddDuration(%q, %v) = %q, want %q", tt.input, tt.d, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ixdtf.AddDuration("bogus", time.Hour); err == nil {
+		t.Error("AddDuration() expected error for unparseable input")
+	}
+}
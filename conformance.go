@@ -0,0 +1,62 @@
+package ixdtf
+
+import "fmt"
+
+// ConformanceCase is one entry of a conformance corpus for RunConformance.
+type ConformanceCase struct {
+	// Input is the string under test.
+	Input string
+
+	// ValidStrict and ValidNonStrict state whether Parse and Validate are
+	// expected to accept Input in strict and non-strict mode.
+	ValidStrict    bool
+	ValidNonStrict bool
+
+	// Canonical, when not empty, is the expected result of a non-strict
+	// RoundTrip of Input, i.e. Parse followed by FormatNano.
+	Canonical string
+}
+
+// RunConformance checks each case against this library and returns one error
+// per mismatch, naming the input, the function and mode, and the expected and
+// actual outcome; nil means every case conforms. Parse and Validate are both
+// checked in both modes, so a disagreement between them is reported too.
+// This lets a downstream project run its own corpus, such as the examples of
+// RFC 9557 or another implementation's test vectors, against this library.
+func RunConformance(cases []ConformanceCase) []error {
+	var errs []error
+	for _, c := range cases {
+		for _, mode := range [...]struct {
+			strict bool
+			valid  bool
+		}{{true, c.ValidStrict}, {false, c.ValidNonStrict}} {
+			_, _, parseErr := Parse(c.Input, mode.strict)
+			errs = appendConformanceMismatch(errs, c.Input, "Parse", mode.strict, mode.valid, parseErr)
+			validateErr := Validate(c.Input, mode.strict)
+			errs = appendConformanceMismatch(errs, c.Input, "Validate", mode.strict, mode.valid, validateErr)
+		}
+		if c.Canonical == "" {
+			continue
+		}
+		got, err := RoundTrip(c.Input, false)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%q: RoundTrip: want %q, got error: %w", c.Input, c.Canonical, err))
+		case got != c.Canonical:
+			errs = append(errs, fmt.Errorf("%q: RoundTrip: want %q, got %q", c.Input, c.Canonical, got))
+		}
+	}
+	return errs
+}
+
+// appendConformanceMismatch appends an error to errs when the outcome err of
+// fn in the given mode disagrees with wantValid.
+func appendConformanceMismatch(errs []error, input, fn string, strict, wantValid bool, err error) []error {
+	switch {
+	case wantValid && err != nil:
+		return append(errs, fmt.Errorf("%q: %s(strict=%t): want valid, got error: %w", input, fn, strict, err))
+	case !wantValid && err == nil:
+		return append(errs, fmt.Errorf("%q: %s(strict=%t): want error, got valid", input, fn, strict))
+	}
+	return errs
+}
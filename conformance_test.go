@@ -0,0 +1,69 @@
+package ixdtf_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestRunConformance(t *testing.T) {
+	t.Parallel()
+
+	// Examples from RFC 9557 Sections 1.2, 3.3, and 3.4.
+	rfcExamples := []ixdtf.ConformanceCase{
+		{
+			Input:       "1996-12-19T16:39:57-08:00[America/Los_Angeles]",
+			ValidStrict: true, ValidNonStrict: true,
+			Canonical: "1996-12-19T16:39:57-08:00[America/Los_Angeles]",
+		},
+		{
+			Input:       "1996-12-19T16:39:57-08:00[America/Los_Angeles][u-ca=hebrew]",
+			ValidStrict: true, ValidNonStrict: true,
+		},
+		{
+			Input:       "2022-07-08T00:14:07+01:00[Europe/London]",
+			ValidStrict: true, ValidNonStrict: true,
+		},
+		{Input: "2022-07-08T00:14:07+01:00[!Europe/Paris]"},
+		{
+			Input:       "2022-07-08T00:14:07Z[Europe/London]",
+			ValidStrict: true, ValidNonStrict: true,
+			Canonical: "2022-07-08T01:14:07+01:00[Europe/London]",
+		},
+		{Input: "2022-07-08T00:14:07+01:00[knort=blargel]", ValidStrict: true, ValidNonStrict: true},
+		{Input: "2022-07-08T00:14:07+01:00[!knort=blargel]", ValidNonStrict: true},
+		{
+			Input:          "2022-07-08T00:14:07+01:00[+08:45]",
+			ValidNonStrict: true,
+		},
+	}
+	if errs := ixdtf.RunConformance(rfcExamples); len(errs) != 0 {
+		t.Errorf("RunConformance(RFC 9557 examples) = %v, want no mismatches", errs)
+	}
+
+	mismatched := []ixdtf.ConformanceCase{
+		{Input: "not a timestamp", ValidStrict: true, ValidNonStrict: false},
+		{
+			Input:       "2025-01-02T03:04:05.500Z",
+			ValidStrict: true, ValidNonStrict: true,
+			Canonical: "2025-01-02T03:04:05.500Z",
+		},
+	}
+	errs := ixdtf.RunConformance(mismatched)
+	// Parse and Validate in strict mode for the first case, RoundTrip for the second.
+	if len(errs) != 3 {
+		t.Fatalf("RunConformance(mismatched) = %v, want 3 mismatches", errs)
+	}
+	if msg := errs[0].Error(); !strings.Contains(msg, `"not a timestamp"`) || !strings.Contains(msg, "want valid") {
+		t.Errorf("mismatch message = %q, want the input and the expectation", msg)
+	}
+	var parseErr *ixdtf.ParseError
+	if !errors.As(errs[0], &parseErr) {
+		t.Errorf("mismatch %v does not wrap the *ParseError", errs[0])
+	}
+	if msg := errs[2].Error(); !strings.Contains(msg, `want "2025-01-02T03:04:05.500Z", got "2025-01-02T03:04:05.5Z"`) {
+		t.Errorf("RoundTrip mismatch message = %q", msg)
+	}
+}
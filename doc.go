@@ -13,10 +13,22 @@
 //   - format.go: serialization (Section 4.1) and critical output rules (Section 3.3)
 //   - parse.go: RFC 3339 core, unknown local offset (Section 2.2), and orchestration
 //   - suffix.go: suffix grammar (Section 4.1)
+//   - scan.go: allocation-free scanner for the full date-time-ext grammar (Section 4.1), and Explain
 //   - timezone.go: time-zone resolution and consistency (Section 3.4)
+//   - zonename.go: case-insensitive matching of time-zone names
 //   - validate.go: extension semantics (Section 3.3)
-//   - calendar.go: the calendar suffix key (Section 5)
+//   - grammar.go: re-exports of the abnf grammar rules
+//   - calendar.go: the calendar and numbering-system suffix keys (Section 5)
 //   - extensions.go: the suffix data model (Section 3)
+//   - builder.go: fluent construction of extensions
+//   - compare.go: ordering of and arithmetic on IXDTF strings
+//   - conformance.go: running conformance corpora against the library
+//   - julian.go: Julian Day conversion for calendar interoperability
+//   - options.go: functional options for parsing, validation, and formatting
+//   - registry.go: registry of suffix keys recognized by strict parsing
+//   - stream.go: reading and writing newline-delimited IXDTF values
+//   - timestamp.go: the Timestamp wrapper and its encoding interfaces
+//   - trace.go: recording parse decisions for diagnostics
 //   - errors.go: error types and sentinels
 package ixdtf
 
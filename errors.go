@@ -2,6 +2,7 @@ package ixdtf
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/8beeeaaat/ixdtf/abnf"
 )
@@ -9,16 +10,147 @@ import (
 // Common parsing errors.
 var (
 	ErrCriticalExtension            = errors.New("critical extension cannot be processed")
+	ErrDuplicateKey                 = errors.New("duplicate IXDTF suffix key")
+	ErrEmptyBracket                 = errors.New("empty IXDTF suffix bracket \"[]\"")
 	ErrExperimentalExtension        = abnf.ErrExperimentalExtension
+	ErrInvalidBinaryEncoding        = errors.New("invalid IXDTF binary encoding")
 	ErrInvalidExtension             = errors.New("invalid extension format")
 	ErrInvalidSuffix                = errors.New("invalid IXDTF suffix format")
 	ErrInvalidTagCalendarIdentifier = errors.New("invalid calendar tag identifier")
 	ErrInvalidTimezone              = errors.New("invalid timezone name")
 	ErrPrivateExtension             = abnf.ErrPrivateExtension
+	ErrSuffixTooLong                = errors.New("IXDTF suffix exceeds the maximum length")
+	ErrTagTooLong                   = errors.New("IXDTF suffix tag key or value exceeds the maximum length")
 	ErrTimezoneOffsetMismatch       = errors.New("timezone offset does not match the specified timezone")
+	ErrTooManyTags                  = errors.New("IXDTF suffix has too many elements")
+	ErrUnknownNumberingSystem       = errors.New("unknown numbering system identifier")
 )
 
+// ErrInvalidTimeZone is an alias of ErrInvalidTimezone, the name every error
+// path in this package returns.
+//
+// Deprecated: use ErrInvalidTimezone.
+var ErrInvalidTimeZone = ErrInvalidTimezone
+
+// TimezoneErrorKind classifies a TimezoneError.
+type TimezoneErrorKind int
+
+const (
+	// TimezoneErrorInvalid reports a time-zone name that could not be
+	// resolved. It matches ErrInvalidTimezone.
+	TimezoneErrorInvalid TimezoneErrorKind = iota
+
+	// TimezoneErrorOffsetMismatch reports an RFC 3339 offset that disagrees
+	// with the annotated zone at that instant (RFC 9557 Section 3.4). It
+	// matches ErrTimezoneOffsetMismatch.
+	TimezoneErrorOffsetMismatch
+)
+
+// TimezoneError reports a time-zone name that could not be resolved, or one
+// whose offset disagrees with the RFC 3339 offset. It matches
+// ErrInvalidTimezone or ErrTimezoneOffsetMismatch with errors.Is, according
+// to Kind.
+type TimezoneError struct {
+	// Name is the time-zone name as written.
+	Name string
+
+	// Suggestion is the closest known IANA zone name by edit distance, or
+	// empty when none is close enough or the zone list is unavailable.
+	Suggestion string
+
+	// OriginalOffset is the offset written in the RFC 3339 portion, and
+	// ExpectedOffset the zone's offset at that instant, both in seconds east
+	// of UTC. They are set only for TimezoneErrorOffsetMismatch.
+	OriginalOffset int
+	ExpectedOffset int
+
+	// Kind classifies the error.
+	Kind TimezoneErrorKind
+}
+
+func (e *TimezoneError) Error() string {
+	if e.Kind == TimezoneErrorOffsetMismatch {
+		return ErrTimezoneOffsetMismatch.Error() + ": offset " + formatOffset(e.OriginalOffset) +
+			" does not match " + strconv.Quote(e.Name) + " (expected " + formatOffset(e.ExpectedOffset) + ")"
+	}
+	msg := ErrInvalidTimezone.Error() + " " + strconv.Quote(e.Name)
+	if e.Suggestion != "" {
+		msg += " (did you mean " + strconv.Quote(e.Suggestion) + "?)"
+	}
+	return msg
+}
+
+// Unwrap returns ErrTimezoneOffsetMismatch for TimezoneErrorOffsetMismatch
+// and ErrInvalidTimezone otherwise.
+func (e *TimezoneError) Unwrap() error {
+	if e.Kind == TimezoneErrorOffsetMismatch {
+		return ErrTimezoneOffsetMismatch
+	}
+	return ErrInvalidTimezone
+}
+
+// newTimezoneError builds the TimezoneError for name, looking up a
+// suggestion. Only call it once the error is known to be returned, since the
+// lookup scans the zone list.
+func newTimezoneError(name string) error {
+	return &TimezoneError{Name: name, Suggestion: suggestZoneName(name)}
+}
+
+// newOffsetMismatchError builds the TimezoneError for an RFC 3339 offset that
+// disagrees with the zone named name.
+func newOffsetMismatchError(name string, originalOffset, expectedOffset int) error {
+	return &TimezoneError{
+		Name:           name,
+		OriginalOffset: originalOffset,
+		ExpectedOffset: expectedOffset,
+		Kind:           TimezoneErrorOffsetMismatch,
+	}
+}
+
+// formatOffset renders an offset in seconds east of UTC in the RFC 3339
+// "+hh:mm" form, extended to "+hh:mm:ss" when the offset has a seconds
+// part, as local mean times such as Amsterdam's +00:19:32 do.
+func formatOffset(offset int) string {
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes, seconds := offset/3600, offset%3600/60, offset%60
+	b := []byte{sign, byte('0' + hours/10), byte('0' + hours%10), ':', byte('0' + minutes/10), byte('0' + minutes%10)}
+	if seconds != 0 {
+		b = append(b, ':', byte('0'+seconds/10), byte('0'+seconds%10))
+	}
+	return string(b)
+}
+
+// TagError reports the suffix tag that failed a check of an IXDTFExtensions
+// value, as returned by ValidateExtensions, Format, and Builder.Build. It
+// unwraps to the error for the rule the tag broke, such as
+// ErrPrivateExtension or ErrInvalidTagCalendarIdentifier.
+type TagError struct {
+	// Key is the offending tag key.
+	Key string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TagError) Error() string {
+	return "tag key " + strconv.Quote(e.Key) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
 // ParseError represents an error that occurred during IXDTF parsing.
+//
+// Layout names the stage that failed: LayoutRFC3339 for an empty or
+// malformed RFC 3339 portion, and LayoutRFC3339Extended for every error from
+// the suffix onward, including the time-zone consistency check. The message
+// therefore depends only on the stage, not on the kind of suffix error.
 type ParseError struct {
 	Err    error
 	Layout Layout
@@ -46,3 +46,23 @@ func TestParseErrorError(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatOffset(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		offset   int
+		expected string
+	}{
+		{offset: 0, expected: "+00:00"},
+		{offset: 9 * 3600, expected: "+09:00"},
+		{offset: -(3*3600 + 30*60), expected: "-03:30"},
+		{offset: 1172, expected: "+00:19:32"},
+		{offset: -(4*3600 + 56*60 + 2), expected: "-04:56:02"},
+	}
+
+	for _, tc := range tests {
+		if got := formatOffset(tc.offset); got != tc.expected {
+			t.Errorf("formatOffset(%d) = %q, want %q", tc.offset, got, tc.expected)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package ixdtf_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/8beeeaaat/ixdtf"
 )
@@ -22,3 +23,49 @@ func TestParseErrorUnwrap(t *testing.T) {
 		t.Fatalf("expected errors.Is(err, ErrInvalidSuffix), got %v", err)
 	}
 }
+
+// TestErrInvalidTimeZoneAlias verifies that the deprecated spelling matches
+// the errors returned for unknown zones.
+func TestErrInvalidTimeZoneAlias(t *testing.T) {
+	t.Parallel()
+
+	_, _, parseErr := ixdtf.Parse("2025-01-02T03:04:05+09:00[Mars/Olympus]", true)
+	_, offsetErr := ixdtf.OffsetAt("Mars/Olympus", time.Now())
+	for name, err := range map[string]error{"Parse": parseErr, "OffsetAt": offsetErr} {
+		//nolint:staticcheck // The deprecated alias is what this test covers.
+		if !errors.Is(err, ixdtf.ErrInvalidTimezone) || !errors.Is(err, ixdtf.ErrInvalidTimeZone) {
+			t.Errorf("%s error = %v, want a match for both spellings", name, err)
+		}
+	}
+}
+
+// TestErrEmptyBracket verifies that an empty "[]" is reported apart from a
+// bracket that is never closed.
+func TestErrEmptyBracket(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"2025-01-01T00:00:00Z[]", ixdtf.ErrEmptyBracket},
+		{"2025-01-01T00:00:00Z[valid=test][]", ixdtf.ErrEmptyBracket},
+		{"2025-01-01T00:00:00Z[Asia/Tokyo][][u-ca=gregory]", ixdtf.ErrEmptyBracket},
+		{"2025-01-01T00:00:00Z[Asia/Tokyo", ixdtf.ErrInvalidSuffix},
+		{"2025-01-01T00:00:00Z[valid=test][", ixdtf.ErrInvalidSuffix},
+	}
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			_, _, parseErr := ixdtf.Parse(tt.input, strict)
+			validateErr := ixdtf.Validate(tt.input, strict)
+			for name, err := range map[string]error{"Parse": parseErr, "Validate": validateErr} {
+				if !errors.Is(err, tt.want) {
+					t.Errorf("%s(%q, %t) error = %v, want %v", name, tt.input, strict, err, tt.want)
+				}
+				if errors.Is(tt.want, ixdtf.ErrInvalidSuffix) && errors.Is(err, ixdtf.ErrEmptyBracket) {
+					t.Errorf("%s(%q, %t) error = %v, want no ErrEmptyBracket", name, tt.input, strict, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package ixdtf_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func ExampleParse() {
+	t, ext, err := ixdtf.Parse("2025-01-02T12:04:05+09:00[Asia/Tokyo][u-ca=japanese]", true)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	calendar, _ := ext.CalendarSystem()
+	fmt.Println(t.UTC(), ext.TimeZoneName(), calendar)
+	// Output: 2025-01-02 03:04:05 +0000 UTC Asia/Tokyo japanese
+}
+
+func ExampleParseLenient() {
+	// A non-strict parse ignores an unknown, non-critical time zone.
+	t, ext, err := ixdtf.ParseLenient("2025-01-02T12:04:05+09:00[Mars/Olympus]")
+	fmt.Printf("%v %q %v\n", t, ext.TimeZoneName(), err)
+	// Output: 2025-01-02 12:04:05 +0900 +0900 "" <nil>
+}
+
+func ExampleParseStrict() {
+	_, _, err := ixdtf.ParseStrict("2025-01-02T12:04:05+09:00[Mars/Olympus]")
+	fmt.Println(errors.Is(err, ixdtf.ErrInvalidTimezone))
+	// Output: true
+}
+
+func ExampleFormat() {
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Location: time.FixedZone("Asia/Tokyo", 9*60*60),
+		Tags:     map[string]string{ixdtf.ExtensionUnicodeCalendar: "japanese"},
+		Critical: map[string]bool{ixdtf.ExtensionUnicodeCalendar: true},
+	})
+	t := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	fmt.Println(ixdtf.MustFormat(t.In(ext.Location), ext))
+	// Output: 2025-01-02T12:04:05+09:00[Asia/Tokyo][!u-ca=japanese]
+}
+
+func ExampleFormatOrEmpty() {
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Tags: map[string]string{"Not_A_Key": "x"},
+	})
+	fmt.Printf("%q\n", ixdtf.FormatOrEmpty(time.Now(), ext))
+	// Output: ""
+}
@@ -1,6 +1,14 @@
 package ixdtf
 
-import "time"
+import (
+	"iter"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // IXDTFExtensions holds IXDTF suffix information that extends RFC 3339.
 //
@@ -16,11 +24,63 @@ type IXDTFExtensions struct {
 
 	// Tags contains extension tags as key-value pairs.
 	// Example: map[ExtensionUnicodeCalendar]"japanese".
+	//
+	// Parse leaves Tags and Critical nil until a tag needs them, so the
+	// common case without tags does not allocate. Reading a nil map is
+	// safe; write through SetTag and SetCritical, which create the maps.
 	Tags map[string]string
 
 	// Critical indicates which tags are marked as critical (must be processed).
 	// Critical tags are marked with "!" prefix in the IXDTF string.
 	Critical map[string]bool
+
+	// Offset is the UTC offset, in seconds east of UTC, stated by the RFC
+	// 3339 portion of a parsed string. It is set by Parse even when it
+	// disagrees with Location, so a mismatch can be reported without
+	// re-parsing; "Z" and "-00:00" give 0. It is nil for extensions not
+	// produced by parsing. With WithPreserveSourceOffset, Format writes the
+	// instant at this offset rather than that of the time's location;
+	// otherwise Format ignores it.
+	Offset *int
+
+	// UnknownOffset records that the RFC 3339 portion of a parsed string
+	// used the literal "-00:00" unknown local offset (RFC 3339 Section 4.3),
+	// which time.Parse otherwise reads like "+00:00". When set, Format writes
+	// the instant in UTC with a "-00:00" offset so the distinction survives a
+	// round trip.
+	UnknownOffset bool
+
+	// ZuluOffset records that the RFC 3339 portion of a parsed string used
+	// the "Z" designator rather than a numeric offset. Parse still applies a
+	// time-zone annotation to such a time, so "Z[Asia/Tokyo]" yields Tokyo
+	// wall time; WithPreserveZuluForm makes Format write it in "Z" form again.
+	ZuluOffset bool
+
+	// KeysLowercased reports whether a non-strict parse with
+	// WithLowercaseKeys rewrote at least one upper-case suffix key.
+	KeysLowercased bool
+
+	// Duplicates lists, in input order, the elective tags that a parse with
+	// WithCollectDuplicates dropped because an earlier tag had the same key
+	// (RFC 9557 Section 3.3: the first occurrence wins). Format ignores it.
+	Duplicates []Tag
+
+	// ZoneResolved reports whether Parse resolved the time-zone annotation
+	// of the string to Location. Together with TimeZoneName it identifies an
+	// annotation that a non-strict parse with WithRecordDroppedZone ignored:
+	// the name is set but ZoneResolved is false.
+	ZoneResolved bool
+
+	// droppedZone is the unresolvable time-zone name recorded by
+	// WithRecordDroppedZone; TimeZoneName reports it when Location is nil.
+	droppedZone string
+}
+
+// Tag is a single key=value suffix tag and its critical "!" flag.
+type Tag struct {
+	Key      string
+	Value    string
+	Critical bool
 }
 
 // NewIXDTFExtensionsArgs contains the arguments for creating IXDTFExtensions.
@@ -44,11 +104,325 @@ func NewIXDTFExtensions(args *NewIXDTFExtensionsArgs) *IXDTFExtensions {
 		Tags:             args.Tags,
 		Critical:         args.Critical,
 	}
-	if ext.Tags == nil {
-		ext.Tags = make(map[string]string)
+	ext.ensureTagMaps()
+	return ext
+}
+
+// ensureTagMaps creates the Tags and Critical maps if they are nil.
+func (e *IXDTFExtensions) ensureTagMaps() {
+	if e.Tags == nil {
+		e.Tags = make(map[string]string)
 	}
-	if ext.Critical == nil {
-		ext.Critical = make(map[string]bool)
+	if e.Critical == nil {
+		e.Critical = make(map[string]bool)
 	}
+}
+
+// ExtensionsFromTime returns extensions with empty tag maps whose Location
+// is the zone t already carries, chosen by the rules Format applies to a
+// timestamp without ext.Location: UTC and unnamed fixed zones give no
+// Location, and time.Local gives the system zone when it can be determined.
+// Format(t, ExtensionsFromTime(t)) therefore keeps t's zone annotation.
+func ExtensionsFromTime(t time.Time) *IXDTFExtensions {
+	ext := NewIXDTFExtensions(nil)
+	ext.Location = formatLocation(t, ext, &formatOptions{})
 	return ext
 }
+
+// LogValue implements slog.LogValuer. It renders the extensions as a group
+// with a "timezone" name, a "tags" group keyed by tag in sorted order, and a
+// "critical" list of the critical tag keys; a critical time-zone annotation
+// adds "timezone_critical". A nil or empty value renders as an empty group.
+func (e *IXDTFExtensions) LogValue() slog.Value {
+	if e == nil {
+		return slog.GroupValue()
+	}
+	var attrs []slog.Attr
+	if e.Location != nil {
+		attrs = append(attrs, slog.String("timezone", e.Location.String()))
+		if e.CriticalLocation {
+			attrs = append(attrs, slog.Bool("timezone_critical", true))
+		}
+	}
+	if len(e.Tags) > 0 {
+		tags := make([]any, 0, len(e.Tags))
+		for _, key := range slices.Sorted(maps.Keys(e.Tags)) {
+			tags = append(tags, slog.String(key, e.Tags[key]))
+		}
+		attrs = append(attrs, slog.Group("tags", tags...))
+	}
+	var critical []string
+	for key, isCritical := range e.Critical {
+		if isCritical {
+			critical = append(critical, key)
+		}
+	}
+	if len(critical) > 0 {
+		slices.Sort(critical)
+		attrs = append(attrs, slog.Any("critical", critical))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// ForEachTag calls f for each tag in the order Format emits them: keys with
+// a valid suffix-key syntax, sorted. Keys Format would skip are skipped here
+// too, so iterating matches the formatted output. It does nothing when e is
+// nil.
+func (e *IXDTFExtensions) ForEachTag(f func(key, value string, critical bool)) {
+	if e == nil {
+		return
+	}
+	for _, key := range sortedTagKeys(e) {
+		f(key, e.Tags[key], e.Critical[key])
+	}
+}
+
+// TagList returns the tags with their critical flags in the order Format
+// emits them: keys with a valid suffix-key syntax, sorted. The slice is
+// freshly allocated on each call. It returns an empty, non-nil slice when e
+// is nil or has no tags.
+func (e *IXDTFExtensions) TagList() []Tag {
+	keys := e.Keys()
+	tags := make([]Tag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, Tag{Key: key, Value: e.Tags[key], Critical: e.Critical[key]})
+	}
+	return tags
+}
+
+// Tags2 returns an iterator over the tags as key/value pairs in the order
+// ForEachTag visits them, for use as "for key, value := range e.Tags2()".
+// It yields nothing when e is nil.
+func (e *IXDTFExtensions) Tags2() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		if e == nil {
+			return
+		}
+		for _, key := range sortedTagKeys(e) {
+			if !yield(key, e.Tags[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Critical2 returns an iterator over the keys CriticalKeys returns, in the
+// same order. It yields nothing when e is nil.
+func (e *IXDTFExtensions) Critical2() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if e == nil {
+			return
+		}
+		for _, key := range sortedTagKeys(e) {
+			if e.Critical[key] && !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// SetTag sets the suffix tag key=value after checking the key against the
+// suffix-key grammar and the value against the suffix-values grammar (RFC
+// 9557 Section 4.1). An existing key keeps its critical flag.
+func (e *IXDTFExtensions) SetTag(key, value string) error {
+	if err := validateTag(key, value); err != nil {
+		return err
+	}
+	if e.Tags == nil {
+		e.Tags = make(map[string]string)
+	}
+	e.Tags[key] = value
+	return nil
+}
+
+// RemoveTag removes the tag key together with its critical flag, so a
+// critical key never outlives its tag.
+func (e *IXDTFExtensions) RemoveTag(key string) {
+	delete(e.Tags, key)
+	delete(e.Critical, key)
+}
+
+// SetCritical sets or clears the critical flag of the tag key. Marking a key
+// critical that is not in Tags fails with ErrCriticalExtension, since Format
+// would reject the result.
+func (e *IXDTFExtensions) SetCritical(key string, critical bool) error {
+	if !critical {
+		delete(e.Critical, key)
+		return nil
+	}
+	if _, ok := e.Tags[key]; !ok {
+		return ErrCriticalExtension
+	}
+	if e.Critical == nil {
+		e.Critical = make(map[string]bool)
+	}
+	e.Critical[key] = true
+	return nil
+}
+
+// Keys returns the tag keys in the order Format emits them: keys with a
+// valid suffix-key syntax, sorted. It returns an empty, non-nil slice when e
+// is nil or has no tags.
+func (e *IXDTFExtensions) Keys() []string {
+	if e == nil {
+		return []string{}
+	}
+	return sortedTagKeys(e)
+}
+
+// CriticalKeys returns the subset of Keys that Format emits with a critical
+// "!" flag, in the same order. It returns an empty, non-nil slice when there
+// are none.
+func (e *IXDTFExtensions) CriticalKeys() []string {
+	keys := e.Keys()
+	critical := keys[:0]
+	for _, key := range keys {
+		if e.Critical[key] {
+			critical = append(critical, key)
+		}
+	}
+	return critical
+}
+
+// TimeZoneName returns the declared time-zone annotation as written back by
+// Format: the IANA name (e.g. "Asia/Tokyo") or numeric offset (e.g.
+// "+09:00") of Location. Without a Location it returns the unresolvable name
+// recorded by WithRecordDroppedZone, which Format does not emit, or "" when e
+// is nil or declares no zone.
+func (e *IXDTFExtensions) TimeZoneName() string {
+	if e == nil {
+		return ""
+	}
+	if e.Location == nil {
+		return e.droppedZone
+	}
+	return e.Location.String()
+}
+
+// HasCritical reports whether e carries any critical "!" annotation: a
+// critical time-zone annotation or a tag flagged in Critical. Per RFC 9557
+// Section 3.3 a recipient must be able to process each of them.
+func (e *IXDTFExtensions) HasCritical() bool {
+	if e == nil {
+		return false
+	}
+	if e.CriticalLocation {
+		return true
+	}
+	for _, critical := range e.Critical {
+		if critical {
+			return true
+		}
+	}
+	return false
+}
+
+// CalendarSystem returns the value of the ExtensionUnicodeCalendar ("u-ca")
+// tag, such as "japanese", and whether the tag is present.
+func (e *IXDTFExtensions) CalendarSystem() (string, bool) {
+	return e.tag(ExtensionUnicodeCalendar)
+}
+
+// NumberingSystem returns the value of the ExtensionUnicodeNumberingSystem
+// ("u-nu") tag, such as "arab", and whether the tag is present.
+func (e *IXDTFExtensions) NumberingSystem() (string, bool) {
+	return e.tag(ExtensionUnicodeNumberingSystem)
+}
+
+// tag returns the value of the tag key, treating a nil e as having no tags.
+func (e *IXDTFExtensions) tag(key string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	value, ok := e.Tags[key]
+	return value, ok
+}
+
+// String implements fmt.Stringer by rendering the IXDTF suffix Format would
+// append for these extensions, e.g. "[Asia/Tokyo][!u-ca=gregory]": the
+// time-zone annotation of Location first, then the tags sorted by key, with
+// critical "!" markers. Tags with an invalid key are skipped as by Format.
+// A nil or empty e returns "".
+func (e *IXDTFExtensions) String() string {
+	if e == nil {
+		return ""
+	}
+	var loc *time.Location
+	if e.Location != nil && e.Location.String() != "" {
+		loc = e.Location
+	}
+	return string(appendAnnotations(nil, loc, e))
+}
+
+// GoString implements fmt.GoStringer, so %#v prints the extensions compactly,
+// e.g. ixdtf.IXDTFExtensions{Loc:"Asia/Tokyo", Tags:{t-format:iso, u-ca:gregory!}}.
+// A critical tag or time zone is marked with a trailing "!", tags are sorted,
+// critical flags without a tag are listed under Critical, dropped duplicate
+// tags under Duplicates in input order, and set boolean fields by name.
+// Empty parts are omitted.
+func (e *IXDTFExtensions) GoString() string {
+	if e == nil {
+		return "(*ixdtf.IXDTFExtensions)(nil)"
+	}
+	var parts []string
+	if e.Location != nil {
+		loc := strconv.Quote(e.Location.String())
+		if e.CriticalLocation {
+			loc += "!"
+		}
+		parts = append(parts, "Loc:"+loc)
+	} else if e.CriticalLocation {
+		parts = append(parts, "CriticalLocation")
+	}
+	if e.droppedZone != "" {
+		parts = append(parts, "DroppedZone:"+strconv.Quote(e.droppedZone))
+	}
+	if e.Offset != nil {
+		parts = append(parts, "Offset:"+strconv.Itoa(*e.Offset))
+	}
+	if len(e.Tags) > 0 {
+		tags := make([]string, 0, len(e.Tags))
+		for _, key := range slices.Sorted(maps.Keys(e.Tags)) {
+			tag := key + ":" + e.Tags[key]
+			if e.Critical[key] {
+				tag += "!"
+			}
+			tags = append(tags, tag)
+		}
+		parts = append(parts, "Tags:{"+strings.Join(tags, ", ")+"}")
+	}
+	var dangling []string
+	for key, critical := range e.Critical {
+		if _, ok := e.Tags[key]; critical && !ok {
+			dangling = append(dangling, key)
+		}
+	}
+	if len(dangling) > 0 {
+		slices.Sort(dangling)
+		parts = append(parts, "Critical:{"+strings.Join(dangling, ", ")+"}")
+	}
+	if len(e.Duplicates) > 0 {
+		dups := make([]string, 0, len(e.Duplicates))
+		for _, tag := range e.Duplicates {
+			dup := tag.Key + ":" + tag.Value
+			if tag.Critical {
+				dup += "!"
+			}
+			dups = append(dups, dup)
+		}
+		parts = append(parts, "Duplicates:{"+strings.Join(dups, ", ")+"}")
+	}
+	if e.UnknownOffset {
+		parts = append(parts, "UnknownOffset")
+	}
+	if e.ZuluOffset {
+		parts = append(parts, "ZuluOffset")
+	}
+	if e.KeysLowercased {
+		parts = append(parts, "KeysLowercased")
+	}
+	if e.ZoneResolved {
+		parts = append(parts, "ZoneResolved")
+	}
+	return "ixdtf.IXDTFExtensions{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,487 @@
+package ixdtf_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestExtensionsFromTime(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		wantZone string
+		want     string
+	}{
+		{"named zone", base.In(tokyo), "Asia/Tokyo", "2025-01-02T12:04:05+09:00[Asia/Tokyo]"},
+		{"UTC", base, "", "2025-01-02T03:04:05Z"},
+		{"unnamed fixed zone", base.In(time.FixedZone("", 3600)), "", "2025-01-02T04:04:05+01:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ext := ixdtf.ExtensionsFromTime(tt.t)
+			if got := ext.TimeZoneName(); got != tt.wantZone {
+				t.Errorf("TimeZoneName() = %q, want %q", got, tt.wantZone)
+			}
+			if ext.Tags == nil || ext.Critical == nil {
+				t.Error("expected initialized tag maps")
+			}
+			got, err := ixdtf.Format(tt.t, ext)
+			if err != nil {
+				t.Fatalf("Format() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIXDTFExtensionsLogValue(t *testing.T) {
+	t.Parallel()
+	tokyo, _, _ := getTestTimezones()
+
+	tests := []struct {
+		name string
+		ext  *ixdtf.IXDTFExtensions
+		want string
+	}{
+		{
+			name: "nil extensions",
+			ext:  nil,
+			want: `{"msg":"parsed"}`,
+		},
+		{
+			name: "empty extensions",
+			ext:  ixdtf.NewIXDTFExtensions(nil),
+			want: `{"msg":"parsed"}`,
+		},
+		{
+			name: "timezone, tags and critical",
+			ext: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+				Location:         tokyo,
+				CriticalLocation: true,
+				Tags:             map[string]string{"u-ca": "gregory", "t-format": "iso"},
+				Critical:         map[string]bool{"u-ca": true},
+			}),
+			want: `{"msg":"parsed","ext":{"timezone":"Asia/Tokyo","timezone_critical":true,` +
+				`"tags":{"t-format":"iso","u-ca":"gregory"},"critical":["u-ca"]}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("parsed", "ext", tc.ext)
+			if got := strings.TrimSpace(buf.String()); got != tc.want {
+				t.Errorf("log output = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIXDTFExtensionsForEachTag(t *testing.T) {
+	t.Parallel()
+
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Tags:     map[string]string{"u-ca": "gregory", "a-key": "1", "b-key": "2"},
+		Critical: map[string]bool{"b-key": true},
+	})
+
+	var b strings.Builder
+	ext.ForEachTag(func(key, value string, critical bool) {
+		b.WriteByte('[')
+		if critical {
+			b.WriteByte('!')
+		}
+		b.WriteString(key + "=" + value + "]")
+	})
+
+	formatted, err := ixdtf.Format(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), ext)
+	if err != nil {
+		t.Fatalf("Format() unexpected error: %v", err)
+	}
+	want := "[a-key=1][!b-key=2][u-ca=gregory]"
+	if b.String() != want || ixdtf.SuffixOf(formatted) != want {
+		t.Errorf("ForEachTag() order = %q, Format() suffix = %q, want %q", b.String(), ixdtf.SuffixOf(formatted), want)
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	nilExt.ForEachTag(func(string, string, bool) { t.Error("ForEachTag() on nil called f") })
+}
+
+func TestIXDTFExtensionsIterators(t *testing.T) {
+	t.Parallel()
+
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Tags:     map[string]string{"u-ca": "gregory", "a-key": "1", "b-key": "2", "Bad": "x"},
+		Critical: map[string]bool{"u-ca": true, "b-key": true},
+	})
+
+	var pairs []string
+	for key, value := range ext.Tags2() {
+		pairs = append(pairs, key+"="+value)
+	}
+	if want := []string{"a-key=1", "b-key=2", "u-ca=gregory"}; !slices.Equal(pairs, want) {
+		t.Errorf("Tags2() = %v, want %v", pairs, want)
+	}
+	if got := slices.Collect(ext.Critical2()); !slices.Equal(got, ext.CriticalKeys()) {
+		t.Errorf("Critical2() = %v, want %v", got, ext.CriticalKeys())
+	}
+
+	calls := 0
+	ext.Tags2()(func(string, string) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("Tags2() kept yielding after a stop: %d calls", calls)
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	for key := range nilExt.Tags2() {
+		t.Errorf("Tags2() on nil yielded %q", key)
+	}
+	for key := range nilExt.Critical2() {
+		t.Errorf("Critical2() on nil yielded %q", key)
+	}
+}
+
+func TestIXDTFExtensionsTagList(t *testing.T) {
+	t.Parallel()
+
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Tags:     map[string]string{"u-ca": "gregory", "a-key": "1", "b-key": "2", "Bad": "x"},
+		Critical: map[string]bool{"u-ca": true, "a-key": false},
+	})
+	want := []ixdtf.Tag{
+		{Key: "a-key", Value: "1"},
+		{Key: "b-key", Value: "2"},
+		{Key: "u-ca", Value: "gregory", Critical: true},
+	}
+	got := ext.TagList()
+	if !slices.Equal(got, want) {
+		t.Errorf("TagList() = %+v, want %+v", got, want)
+	}
+
+	got[0].Value = "changed"
+	if again := ext.TagList(); !slices.Equal(again, want) || ext.Tags["a-key"] != "1" {
+		t.Errorf("TagList() after modifying a previous result = %+v, want %+v", again, want)
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	if got := nilExt.TagList(); got == nil || len(got) != 0 {
+		t.Errorf("TagList() on nil = %#v, want empty non-nil", got)
+	}
+}
+
+func TestIXDTFExtensionsMutators(t *testing.T) {
+	t.Parallel()
+
+	// criticalKeysExist checks the invariant the mutators maintain.
+	criticalKeysExist := func(t *testing.T, ext *ixdtf.IXDTFExtensions) {
+		t.Helper()
+		for key, critical := range ext.Critical {
+			if _, ok := ext.Tags[key]; critical && !ok {
+				t.Errorf("critical key %q has no tag: %+v", key, ext)
+			}
+		}
+	}
+
+	ext := &ixdtf.IXDTFExtensions{}
+	if err := ext.SetTag("u-ca", "gregory"); err != nil {
+		t.Fatalf("SetTag() on zero value unexpected error: %v", err)
+	}
+	if err := ext.SetCritical("u-ca", true); err != nil {
+		t.Fatalf("SetCritical() unexpected error: %v", err)
+	}
+	if err := ext.SetTag("u-ca", "hebrew"); err != nil || !ext.Critical["u-ca"] {
+		t.Fatalf("SetTag() on existing key = %v, critical = %t; want nil, true", err, ext.Critical["u-ca"])
+	}
+	criticalKeysExist(t, ext)
+
+	if err := ext.SetTag("U-CA", "gregory"); err == nil {
+		t.Error("SetTag() expected error for upper-case key")
+	}
+	if err := ext.SetTag("a-key", ""); !errors.Is(err, ixdtf.ErrInvalidExtension) {
+		t.Errorf("SetTag() empty value error = %v, want ErrInvalidExtension", err)
+	}
+	if err := ext.SetTag("a-key", "-bad"); !errors.Is(err, ixdtf.ErrInvalidExtension) {
+		t.Errorf("SetTag() invalid value error = %v, want ErrInvalidExtension", err)
+	}
+	if err := ext.SetCritical("missing", true); !errors.Is(err, ixdtf.ErrCriticalExtension) {
+		t.Errorf("SetCritical() missing key error = %v, want ErrCriticalExtension", err)
+	}
+	criticalKeysExist(t, ext)
+
+	ext.RemoveTag("u-ca")
+	if _, ok := ext.Tags["u-ca"]; ok || ext.Critical["u-ca"] {
+		t.Errorf("RemoveTag() left %+v", ext)
+	}
+	criticalKeysExist(t, ext)
+
+	if err := ext.SetCritical("missing", false); err != nil {
+		t.Errorf("SetCritical(false) missing key unexpected error: %v", err)
+	}
+}
+
+// TestParseLeavesTagMapsNil pins that Parse allocates the tag maps only for a
+// suffix with tags, and that the result stays safe to read and extend.
+func TestParseLeavesTagMapsNil(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"2025-01-02T03:04:05Z", "2025-01-02T12:04:05+09:00[Asia/Tokyo]"} {
+		parsed, ext, err := ixdtf.Parse(input, true)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+		}
+		if ext.Tags != nil || ext.Critical != nil {
+			t.Errorf("Parse(%q) maps = %v, %v, want nil", input, ext.Tags, ext.Critical)
+		}
+		if _, ok := ext.CalendarSystem(); ok || ext.HasCritical() || len(ext.Keys()) != 0 {
+			t.Errorf("Parse(%q) reports tags: %+v", input, ext)
+		}
+		if err := ext.SetTag("u-ca", "gregory"); err != nil {
+			t.Fatalf("SetTag() unexpected error: %v", err)
+		}
+		if err := ext.SetCritical("u-ca", true); err != nil {
+			t.Fatalf("SetCritical() unexpected error: %v", err)
+		}
+		got, err := ixdtf.Format(parsed, ext)
+		if want := input + "[!u-ca=gregory]"; err != nil || got != want {
+			t.Errorf("Format() = %q, %v, want %q", got, err, want)
+		}
+	}
+
+	_, ext, err := ixdtf.Parse("2025-01-02T03:04:05Z[u-ca=gregory]", true)
+	if err != nil || ext.Tags == nil || ext.Critical != nil {
+		t.Errorf("Parse with an elective tag = %+v, %v, want Tags only", ext, err)
+	}
+}
+
+func TestIXDTFExtensionsKeys(t *testing.T) {
+	t.Parallel()
+
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Tags:     map[string]string{"u-ca": "gregory", "t-format": "iso", "a-key": "1", "Bad_Key": "x"},
+		Critical: map[string]bool{"u-ca": true, "t-format": true, "a-key": false, "missing": true},
+	})
+	if got, want := ext.Keys(), []string{"a-key", "t-format", "u-ca"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if got, want := ext.CriticalKeys(), []string{"t-format", "u-ca"}; !slices.Equal(got, want) {
+		t.Errorf("CriticalKeys() = %v, want %v", got, want)
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	for name, got := range map[string][]string{
+		"nil Keys":           nilExt.Keys(),
+		"nil CriticalKeys":   nilExt.CriticalKeys(),
+		"empty Keys":         ixdtf.NewIXDTFExtensions(nil).Keys(),
+		"empty CriticalKeys": ixdtf.NewIXDTFExtensions(nil).CriticalKeys(),
+	} {
+		if got == nil || len(got) != 0 {
+			t.Errorf("%s = %#v, want empty non-nil slice", name, got)
+		}
+	}
+}
+
+func TestIXDTFExtensionsTimeZoneName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-01-02T03:04:05+09:00[Asia/Tokyo]", "Asia/Tokyo"},
+		{"2025-01-02T03:04:05+09:00[!+09:00]", "+09:00"},
+		{"2025-01-02T03:04:05Z[u-ca=gregory]", ""},
+	}
+	for _, tt := range tests {
+		_, ext, err := ixdtf.Parse(tt.input, true)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+		}
+		if got := ext.TimeZoneName(); got != tt.want {
+			t.Errorf("Parse(%q) TimeZoneName() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	if got := nilExt.TimeZoneName(); got != "" {
+		t.Errorf("nil TimeZoneName() = %q, want empty", got)
+	}
+}
+
+func TestIXDTFExtensionsAccessors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input         string
+		wantCritical  bool
+		wantCalendar  string
+		wantNumbering string
+	}{
+		{"2025-01-02T03:04:05Z", false, "", ""},
+		{"2025-01-02T03:04:05Z[u-ca=japanese][u-nu=arab]", false, "japanese", "arab"},
+		{"2025-01-02T03:04:05Z[!u-ca=gregory]", true, "gregory", ""},
+		{"2025-01-02T03:04:05+09:00[!Asia/Tokyo]", true, "", ""},
+	}
+	for _, tt := range tests {
+		_, ext, err := ixdtf.Parse(tt.input, true)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+		}
+		if got := ext.HasCritical(); got != tt.wantCritical {
+			t.Errorf("Parse(%q) HasCritical() = %t, want %t", tt.input, got, tt.wantCritical)
+		}
+		if got, ok := ext.CalendarSystem(); got != tt.wantCalendar || ok != (tt.wantCalendar != "") {
+			t.Errorf("Parse(%q) CalendarSystem() = %q, %t, want %q", tt.input, got, ok, tt.wantCalendar)
+		}
+		if got, ok := ext.NumberingSystem(); got != tt.wantNumbering || ok != (tt.wantNumbering != "") {
+			t.Errorf("Parse(%q) NumberingSystem() = %q, %t, want %q", tt.input, got, ok, tt.wantNumbering)
+		}
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	if nilExt.HasCritical() {
+		t.Error("nil HasCritical() = true, want false")
+	}
+	if _, ok := nilExt.CalendarSystem(); ok {
+		t.Error("nil CalendarSystem() reported a calendar")
+	}
+	if _, ok := nilExt.NumberingSystem(); ok {
+		t.Error("nil NumberingSystem() reported a numbering system")
+	}
+}
+
+func TestIXDTFExtensionsString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-01-02T03:04:05+09:00[Asia/Tokyo][!u-ca=gregory]", "[Asia/Tokyo][!u-ca=gregory]"},
+		{"2025-01-02T03:04:05+09:00[!+09:00][t-b=2][t-a=1]", "[!+09:00][t-a=1][t-b=2]"},
+		{"2025-01-02T03:04:05Z", ""},
+	}
+	for _, tt := range tests {
+		_, ext, err := ixdtf.Parse(tt.input, false)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+		}
+		if got := fmt.Sprint(ext); got != tt.want {
+			t.Errorf("fmt.Sprint(Parse(%q)) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	var nilExt *ixdtf.IXDTFExtensions
+	if got := nilExt.String(); got != "" {
+		t.Errorf("nil String() = %q, want empty", got)
+	}
+}
+
+func TestIXDTFExtensionsGoString(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	offset := 9 * 3600
+	_, dropped, err := ixdtf.Parse("2025-01-01T00:00:00Z[No/SuchZone]", false, ixdtf.WithRecordDroppedZone())
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	tests := []struct {
+		name string
+		ext  *ixdtf.IXDTFExtensions
+		want string
+	}{
+		{"nil", nil, "(*ixdtf.IXDTFExtensions)(nil)"},
+		{"empty", &ixdtf.IXDTFExtensions{}, "ixdtf.IXDTFExtensions{}"},
+		{
+			"full",
+			&ixdtf.IXDTFExtensions{
+				Location:         tokyo,
+				CriticalLocation: true,
+				Offset:           &offset,
+				Tags:             map[string]string{"u-ca": "gregory", "t-format": "iso"},
+				Critical:         map[string]bool{"u-ca": true, "missing": true},
+			},
+			`ixdtf.IXDTFExtensions{Loc:"Asia/Tokyo"!, Offset:32400, ` +
+				`Tags:{t-format:iso, u-ca:gregory!}, Critical:{missing}}`,
+		},
+		{
+			"flags",
+			&ixdtf.IXDTFExtensions{
+				CriticalLocation: true,
+				UnknownOffset:    true,
+				ZuluOffset:       true,
+				KeysLowercased:   true,
+				ZoneResolved:     true,
+			},
+			"ixdtf.IXDTFExtensions{CriticalLocation, UnknownOffset, ZuluOffset, KeysLowercased, ZoneResolved}",
+		},
+		{
+			"duplicates",
+			&ixdtf.IXDTFExtensions{
+				Tags: map[string]string{"u-ca": "gregory"},
+				Duplicates: []ixdtf.Tag{
+					{Key: "u-ca", Value: "japanese"},
+					{Key: "u-ca", Value: "iso8601", Critical: true},
+				},
+			},
+			"ixdtf.IXDTFExtensions{Tags:{u-ca:gregory}, Duplicates:{u-ca:japanese, u-ca:iso8601!}}",
+		},
+		{
+			"dropped zone",
+			dropped,
+			`ixdtf.IXDTFExtensions{DroppedZone:"No/SuchZone", Offset:0, ZuluOffset}`,
+		},
+	}
+
+	// A field added to IXDTFExtensions must be added to GoString and to the
+	// cases above as well.
+	covered := []string{
+		"Location", "CriticalLocation", "Tags", "Critical", "Offset",
+		"UnknownOffset", "ZuluOffset", "KeysLowercased", "Duplicates",
+		"ZoneResolved", "droppedZone",
+	}
+	typ := reflect.TypeFor[ixdtf.IXDTFExtensions]()
+	for i := range typ.NumField() {
+		if name := typ.Field(i).Name; !slices.Contains(covered, name) {
+			t.Errorf("GoString does not cover IXDTFExtensions.%s", name)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := fmt.Sprintf("%#v", tt.ext); got != tt.want {
+				t.Errorf("%%#v = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
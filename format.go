@@ -1,7 +1,10 @@
 package ixdtf
 
 import (
+	"maps"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/8beeeaaat/ixdtf/abnf"
@@ -9,41 +12,233 @@ import (
 
 // Format formats a time with IXDTF extensions using RFC 3339 format.
 // The time-zone annotation is emitted with a leading "!" when
-// ext.CriticalLocation is set.
-func Format(t time.Time, ext *IXDTFExtensions) (string, error) {
-	return format(t, ext, time.RFC3339)
+// ext.CriticalLocation is set. Without ext.Location, a timestamp in
+// time.Local is annotated with the IANA name of the system zone when it can
+// be determined (see WithEmitUTCBracket for how), rather than dropping it.
+func Format(t time.Time, ext *IXDTFExtensions, opts ...FormatOption) (string, error) {
+	return format(t, ext, time.RFC3339, newFormatOptions(opts))
 }
 
 // FormatNano formats a time with IXDTF extensions using RFC 3339 format with nanoseconds.
 // The time-zone annotation is emitted with a leading "!" when
 // ext.CriticalLocation is set.
-func FormatNano(t time.Time, ext *IXDTFExtensions) (string, error) {
-	return format(t, ext, time.RFC3339Nano)
+func FormatNano(t time.Time, ext *IXDTFExtensions, opts ...FormatOption) (string, error) {
+	return format(t, ext, time.RFC3339Nano, newFormatOptions(opts))
+}
+
+// MustFormat is like Format but panics if the extensions cannot be
+// formatted. It suits known-good values such as constants and test fixtures.
+func MustFormat(t time.Time, ext *IXDTFExtensions, opts ...FormatOption) string {
+	s, err := Format(t, ext, opts...)
+	if err != nil {
+		panic("ixdtf: Format: " + err.Error())
+	}
+	return s
+}
+
+// FormatOrEmpty is like Format but returns "" when the extensions cannot be
+// formatted.
+func FormatOrEmpty(t time.Time, ext *IXDTFExtensions, opts ...FormatOption) string {
+	s, err := Format(t, ext, opts...)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// FormatChecked is like Format but also runs Validate, with the given
+// strictness, on its own output and returns the Validate error when the
+// string cannot be read back. That catches a value Format accepts but Parse
+// would reject, such as a year beyond 9999, or, with strict, a time whose
+// offset disagrees with a non-critical ext.Location. The check parses the
+// result again, so FormatChecked costs roughly a Format plus a Validate;
+// Format itself is unaffected.
+func FormatChecked(t time.Time, ext *IXDTFExtensions, strict bool, opts ...FormatOption) (string, error) {
+	s, err := Format(t, ext, opts...)
+	if err != nil {
+		return "", err
+	}
+	if err := Validate(s, strict); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// FormatMilli formats a time with IXDTF extensions using RFC 3339 format with
+// exactly three fractional-second digits, zero-padded and never trimmed
+// (e.g. "2025-01-02T03:04:05.120Z"). Sub-millisecond precision is truncated.
+// Extensions are validated as by Format.
+func FormatMilli(t time.Time, ext *IXDTFExtensions, opts ...FormatOption) (string, error) {
+	return format(t, ext, layoutRFC3339Milli, newFormatOptions(opts))
+}
+
+// FormatMicro formats a time with IXDTF extensions using RFC 3339 format with
+// exactly six fractional-second digits, zero-padded and never trimmed.
+// Sub-microsecond precision is truncated. Extensions are validated as by
+// Format.
+func FormatMicro(t time.Time, ext *IXDTFExtensions, opts ...FormatOption) (string, error) {
+	return format(t, ext, layoutRFC3339Micro, newFormatOptions(opts))
+}
+
+// Fixed-precision RFC 3339 layouts used by FormatMilli and FormatMicro.
+const (
+	layoutRFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+	layoutRFC3339Micro = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// layoutFor returns the time layout format uses for base under opts. With
+// WithExplicitUTCOffset, the "Z07:00" zone element of an RFC 3339 layout is
+// replaced by "-07:00" so a zero offset is written as "+00:00" rather than "Z".
+// With WithBasicOffset, the colon is dropped from the zone element.
+func layoutFor(base string, opts *formatOptions) string {
+	trimmed, ok := strings.CutSuffix(base, "Z07:00")
+	if !ok {
+		return base
+	}
+	switch {
+	case opts.explicitUTCOffset && opts.basicOffset:
+		return trimmed + "-0700"
+	case opts.explicitUTCOffset:
+		return trimmed + "-07:00"
+	case opts.basicOffset:
+		return trimmed + "Z0700"
+	default:
+		return base
+	}
+}
+
+// InLocation formats the instant t shifted to loc, with loc as the time-zone
+// annotation and the tags, critical flags, and CriticalLocation of ext
+// carried over. time.Local is annotated with the system zone as Format does.
+// A loc that is not an IANA zone or numeric-offset annotation, such as an
+// unnamed time.FixedZone, contributes only its offset and no annotation. A
+// nil loc returns ErrInvalidTimezone.
+func InLocation(t time.Time, ext *IXDTFExtensions, loc *time.Location) (string, error) {
+	if loc == nil {
+		return "", ErrInvalidTimezone
+	}
+	shifted, shiftedExt := shiftLocation(t, ext, loc)
+	return Format(shifted, shiftedExt)
+}
+
+// shiftLocation returns t shifted to loc and a copy of ext annotated with loc
+// as described for InLocation. The tag maps of the copy are cloned, so it does
+// not alias ext. loc must not be nil.
+func shiftLocation(t time.Time, ext *IXDTFExtensions, loc *time.Location) (time.Time, *IXDTFExtensions) {
+	args := &NewIXDTFExtensionsArgs{}
+	if ext != nil {
+		args.CriticalLocation = ext.CriticalLocation
+		args.Tags = maps.Clone(ext.Tags)
+		args.Critical = maps.Clone(ext.Critical)
+	}
+	shifted := t.In(loc)
+	if loc != time.Local {
+		if _, err := resolveLocation(loc, stdLocationLoader{}); err == nil {
+			args.Location = loc
+		} else {
+			// Strip the name so Format does not fall back to it as an annotation.
+			_, offset := shifted.Zone()
+			shifted = shifted.In(time.FixedZone("", offset))
+		}
+	}
+	return shifted, NewIXDTFExtensions(args)
+}
+
+// StripExtensions parses s and re-emits only its RFC 3339 date-time, for
+// consumers that do not accept an IXDTF suffix. When Parse applies the
+// annotated zone (a consistent offset, or "Z" / "-00:00" with a zone), the
+// result uses that zone's offset, e.g. "2025-01-01T00:00:00Z[Asia/Tokyo]"
+// becomes "2025-01-01T09:00:00+09:00". Fractional seconds are kept without
+// trailing zeros.
+func StripExtensions(s string, strict bool) (string, error) {
+	t, _, err := Parse(s, strict)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// RoundTrip parses s and formats the result again with FormatNano, returning
+// the canonical form of s: tags sorted by key, fractional seconds without
+// trailing zeros, and, when the annotated zone is applied, its offset. An
+// already canonical string is returned unchanged, so comparing the result
+// with s checks that it survives a Parse → Format round trip. Errors from
+// either step are returned as is.
+func RoundTrip(s string, strict bool, opts ...FormatOption) (string, error) {
+	t, ext, err := Parse(s, strict)
+	if err != nil {
+		return "", err
+	}
+	return FormatNano(t, ext, opts...)
 }
 
 // format validates the extensions and serializes the timestamp with its IXDTF
 // suffix. Formatting always validates strictly: the producer of a string must
 // only emit annotations it can process (RFC 9557 Section 3.3).
-func format(t time.Time, ext *IXDTFExtensions, layout string) (string, error) {
-	if err := validateExtensionsStrict(ext, true); err != nil {
+func format(t time.Time, ext *IXDTFExtensions, layout string, opts *formatOptions) (string, error) {
+	if err := validateForFormat(t, ext, opts); err != nil {
 		return "", err
 	}
-	if err := validateCriticalLocation(t, ext); err != nil {
-		return "", err
+	bp, _ := formatBufferPool.Get().(*[]byte)
+	if bp == nil {
+		bp = new([]byte)
 	}
-	return string(appendSuffix(t, ext, layout)), nil
+	b := appendSuffix((*bp)[:0], t, ext, layout, opts)
+	out := string(b)
+	if cap(b) <= maxPooledFormatBuffer {
+		*bp = b
+		formatBufferPool.Put(bp)
+	}
+	return out, nil
+}
+
+// validateForFormat runs the checks format applies before emitting anything.
+func validateForFormat(t time.Time, ext *IXDTFExtensions, opts *formatOptions) error {
+	if err := validateExtensionsStrict(ext, true, stdLocationLoader{}); err != nil {
+		return err
+	}
+	if opts.strictLocation && (ext == nil || ext.Location == nil) {
+		if loc := formatLocation(t, NewIXDTFExtensions(nil), opts); loc != nil {
+			if err := validateLocationStrict(loc, true, stdLocationLoader{}); err != nil {
+				return err
+			}
+		}
+	}
+	return validateCriticalLocation(t, ext, opts)
+}
+
+// maxPooledFormatBuffer caps the capacity of buffers returned to
+// formatBufferPool so one unusually long suffix is not retained.
+const maxPooledFormatBuffer = 1 << 10
+
+// formatBufferPool holds scratch buffers for format, which copies the result
+// out as a string before returning the buffer.
+//
+//nolint:gochecknoglobals // A package-level pool is the only way to share buffers across calls.
+var formatBufferPool = sync.Pool{
+	New: func() any {
+		const initialCapacity = 64
+		b := make([]byte, 0, initialCapacity)
+		return &b
+	},
 }
 
 // formatLocation returns the location whose name is emitted as the time-zone
 // annotation: ext.Location when set, otherwise the timestamp's own named zone.
-// When falling back to the timestamp's zone, UTC, Local, and unnamed zones
-// produce no annotation, so nil is returned.
-func formatLocation(t time.Time, ext *IXDTFExtensions) *time.Location {
+// When falling back to the timestamp's zone, time.Local is resolved to the
+// system zone by systemLocation, and UTC (unless WithEmitUTCBracket is set)
+// and unnamed zones produce no annotation, so nil is returned.
+func formatLocation(t time.Time, ext *IXDTFExtensions, opts *formatOptions) *time.Location {
 	loc := ext.Location
 	if loc == nil {
 		loc = t.Location()
-		if loc == time.UTC || loc.String() == "Local" {
-			return nil
+		switch {
+		case loc == time.UTC:
+			if !opts.emitUTCBracket {
+				return nil
+			}
+		case loc.String() == "Local":
+			return systemLocation(t)
 		}
 	}
 	if loc.String() == "" {
@@ -56,21 +251,55 @@ func formatLocation(t time.Time, ext *IXDTFExtensions) *time.Location {
 // to attach to — neither ext.Location nor the timestamp's own named zone.
 // Emitting output that silently drops the "!" would misrepresent the caller's
 // declared critical intent (RFC 9557 Section 3.3).
-func validateCriticalLocation(t time.Time, ext *IXDTFExtensions) error {
-	if ext != nil && ext.CriticalLocation && formatLocation(t, ext) == nil {
+func validateCriticalLocation(t time.Time, ext *IXDTFExtensions, opts *formatOptions) error {
+	if ext != nil && ext.CriticalLocation && formatLocation(t, ext, opts) == nil {
 		return ErrCriticalExtension
 	}
 	return nil
 }
 
-func appendSuffix(t time.Time, ext *IXDTFExtensions, format string) []byte {
+// appendSuffix appends t formatted with the layout and its IXDTF suffix to b
+// and returns the extended buffer.
+func appendSuffix(b []byte, t time.Time, ext *IXDTFExtensions, layout string, opts *formatOptions) []byte {
 	if ext == nil {
 		ext = NewIXDTFExtensions(nil)
 	}
-	b := t.AppendFormat(nil, format)
+	switch {
+	case ext.UnknownOffset:
+		// Write the UTC instant with a numeric "+00:00" offset and turn its
+		// sign into the "-00:00" unknown local offset.
+		utc := &formatOptions{explicitUTCOffset: true, basicOffset: opts.basicOffset}
+		b = t.UTC().AppendFormat(b, layoutFor(layout, utc))
+		if opts.basicOffset {
+			b[len(b)-len("+0000")] = '-'
+		} else {
+			b[len(b)-len("+00:00")] = '-'
+		}
+	case opts.preserveZuluForm && ext.ZuluOffset:
+		b = t.UTC().AppendFormat(b, layoutFor(layout, &formatOptions{basicOffset: opts.basicOffset}))
+	case opts.preserveSourceOffset && ext.Offset != nil:
+		b = t.In(time.FixedZone("", *ext.Offset)).AppendFormat(b, layoutFor(layout, opts))
+	default:
+		b = t.AppendFormat(b, layoutFor(layout, opts))
+	}
 
+	loc := formatLocation(t, ext, opts)
+	if opts.tagsBeforeTimezone {
+		return appendZoneAnnotation(appendTags(b, ext), loc, ext)
+	}
+	return appendAnnotations(b, loc, ext)
+}
+
+// appendAnnotations appends the bracketed suffix to b: the time-zone
+// annotation for loc, if any, then the tags of ext in sortedTagKeys order.
+func appendAnnotations(b []byte, loc *time.Location, ext *IXDTFExtensions) []byte {
+	return appendTags(appendZoneAnnotation(b, loc, ext), ext)
+}
+
+// appendZoneAnnotation appends the time-zone annotation for loc, if any.
+func appendZoneAnnotation(b []byte, loc *time.Location, ext *IXDTFExtensions) []byte {
 	// Add timezone if we have a valid location to display
-	if loc := formatLocation(t, ext); loc != nil {
+	if loc != nil {
 		b = append(b, '[')
 		if ext.CriticalLocation {
 			b = append(b, '!')
@@ -78,20 +307,13 @@ func appendSuffix(t time.Time, ext *IXDTFExtensions, format string) []byte {
 		b = append(b, loc.String()...)
 		b = append(b, ']')
 	}
+	return b
+}
 
-	// set tags
-	keys := make([]string, 0, len(ext.Tags))
-	for key := range ext.Tags {
-		keys = append(keys, key)
-	}
-
-	sort.Strings(keys)
-
+// appendTags appends the tags of ext in sortedTagKeys order.
+func appendTags(b []byte, ext *IXDTFExtensions) []byte {
 	// Append tags in sorted order for consistency
-	for _, key := range keys {
-		if err := abnf.AbnfSuffixKey.ValidateSuffixKey(key); err != nil {
-			continue
-		}
+	for _, key := range sortedTagKeys(ext) {
 		value := ext.Tags[key]
 		b = append(b, '[')
 		if ext.Critical[key] {
@@ -102,6 +324,28 @@ func appendSuffix(t time.Time, ext *IXDTFExtensions, format string) []byte {
 		b = append(b, value...)
 		b = append(b, ']')
 	}
-
 	return b
 }
+
+// sortedTagKeys returns the tag keys appendSuffix emits, in emission order:
+// keys with a valid suffix-key syntax, sorted. Invalid keys are skipped.
+func sortedTagKeys(ext *IXDTFExtensions) []string {
+	keys := make([]string, 0, len(ext.Tags))
+	for key := range ext.Tags {
+		if err := abnf.AbnfSuffixKey.ValidateSuffixKey(key); err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CriticalManifest returns the sorted, comma-separated list of the tag keys
+// that Format emits with a critical "!" flag (e.g. "t-format,u-ca"), so a
+// transport layer can echo it in a header for intermediaries that must
+// process them. It returns an empty string when ext is nil or has no
+// critical tags.
+func CriticalManifest(ext *IXDTFExtensions) string {
+	return strings.Join(ext.CriticalKeys(), ",")
+}
@@ -15,7 +15,8 @@ func TestAppendSuffix(t *testing.T) {
 		ext.Tags["valid"] = "ok"
 		ext.Critical["valid"] = true
 
-		formatted := string(appendSuffix(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ext, time.RFC3339))
+		ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		formatted := string(appendSuffix(nil, ts, ext, time.RFC3339, &formatOptions{}))
 		if strings.Contains(formatted, "InvalidKey") {
 			t.Fatalf("expected invalid key to be skipped, got %q", formatted)
 		}
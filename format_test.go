@@ -359,3 +359,825 @@ func TestFormatNano(t *testing.T) {
 		})
 	}
 }
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		strict  bool
+		want    string
+		wantErr error
+	}{
+		{
+			"canonical input is unchanged", "2025-01-02T12:04:05.5+09:00[Asia/Tokyo][!u-ca=japanese]", true,
+			"2025-01-02T12:04:05.5+09:00[Asia/Tokyo][!u-ca=japanese]", nil,
+		},
+		{"tags are sorted", "2025-01-02T03:04:05Z[t-b=2][t-a=1]", true, "2025-01-02T03:04:05Z[t-a=1][t-b=2]", nil},
+		{"trailing zeros are trimmed", "2025-01-02T03:04:05.500Z", true, "2025-01-02T03:04:05.5Z", nil},
+		{
+			"unknown offset takes the zone", "2025-01-02T03:04:05Z[Asia/Tokyo]", true,
+			"2025-01-02T12:04:05+09:00[Asia/Tokyo]", nil,
+		},
+		{"parse error", "2025-01-02T12:04:05+09:00[America/New_York]", true, "", ixdtf.ErrTimezoneOffsetMismatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ixdtf.RoundTrip(tc.input, tc.strict)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("RoundTrip(%q) error = %v, want %v", tc.input, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RoundTrip(%q) unexpected error: %v", tc.input, err)
+			}
+			if got != tc.want {
+				t.Errorf("RoundTrip(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatWithPreserveSourceOffset(t *testing.T) {
+	t.Parallel()
+
+	preserve := ixdtf.WithPreserveSourceOffset()
+	tests := []struct {
+		name    string
+		input   string
+		without string
+	}{
+		{
+			"consistent offset", "2025-06-07T08:09:10+02:00[Europe/Paris][u-ca=gregory]",
+			"2025-06-07T08:09:10+02:00[Europe/Paris][u-ca=gregory]",
+		},
+		{
+			"inconsistent offset kept by non-strict parse", "2025-06-07T08:09:10+01:00[Europe/Paris]",
+			"2025-06-07T08:09:10+01:00[Europe/Paris]",
+		},
+		{"Z with a zone", "2025-06-07T08:09:10.25Z[Asia/Tokyo]", "2025-06-07T17:09:10.25+09:00[Asia/Tokyo]"},
+		{"unknown local offset", "2025-06-07T08:09:10-00:00[Asia/Tokyo]", "2025-06-07T08:09:10-00:00[Asia/Tokyo]"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			parsed, ext, err := ixdtf.Parse(tc.input, false)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tc.input, err)
+			}
+			if got, _ := ixdtf.FormatNano(parsed, ext, preserve); got != tc.input {
+				t.Errorf("FormatNano() = %q, want the input %q", got, tc.input)
+			}
+			if got, _ := ixdtf.FormatNano(parsed, ext); got != tc.without {
+				t.Errorf("FormatNano() without the option = %q, want %q", got, tc.without)
+			}
+		})
+	}
+
+	// The stored offset is written even after the instant moves across DST.
+	parsed, ext := ixdtf.MustParse("2025-03-30T00:30:00+01:00[Europe/Paris]")
+	got, err := ixdtf.Format(parsed.Add(2*time.Hour), ext, preserve)
+	if err != nil {
+		t.Fatalf("Format() unexpected error: %v", err)
+	}
+	if want := "2025-03-30T02:30:00+01:00[Europe/Paris]"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatWithPreserveZuluForm(t *testing.T) {
+	t.Parallel()
+
+	zulu := ixdtf.WithPreserveZuluForm()
+	tests := []struct {
+		input   string
+		without string
+	}{
+		// "Z" is an unknown local offset, so Parse applies the zone in both
+		// modes and only the option restores the source form.
+		{"2025-01-02T03:04:05Z[Asia/Tokyo]", "2025-01-02T12:04:05+09:00[Asia/Tokyo]"},
+		{
+			"2025-01-02T03:04:05.25Z[!Asia/Tokyo][u-ca=japanese]",
+			"2025-01-02T12:04:05.25+09:00[!Asia/Tokyo][u-ca=japanese]",
+		},
+		{"2025-01-02T03:04:05Z", "2025-01-02T03:04:05Z"},
+		// Numeric offsets, including "+00:00", are not affected.
+		{"2025-01-02T12:04:05+09:00[Asia/Tokyo]", "2025-01-02T12:04:05+09:00[Asia/Tokyo]"},
+		{"2025-01-02T03:04:05+00:00[UTC]", "2025-01-02T03:04:05Z[UTC]"},
+	}
+	for _, tc := range tests {
+		for _, strict := range []bool{false, true} {
+			parsed, ext, err := ixdtf.Parse(tc.input, strict)
+			if err != nil {
+				t.Fatalf("Parse(%q, %t) unexpected error: %v", tc.input, strict, err)
+			}
+			want := tc.input
+			if !ext.ZuluOffset {
+				want = tc.without
+			}
+			if got, _ := ixdtf.FormatNano(parsed, ext, zulu); got != want {
+				t.Errorf("FormatNano(Parse(%q, %t)) = %q, want %q", tc.input, strict, got, want)
+			}
+			if got, _ := ixdtf.FormatNano(parsed, ext); got != tc.without {
+				t.Errorf("FormatNano(Parse(%q, %t)) without the option = %q, want %q",
+					tc.input, strict, got, tc.without)
+			}
+		}
+	}
+}
+
+func TestFormatWithBasicOffset(t *testing.T) {
+	t.Parallel()
+
+	basic := ixdtf.WithBasicOffset()
+	tests := []struct {
+		input string
+		opts  []ixdtf.FormatOption
+		want  string
+	}{
+		{"2025-01-02T12:04:05+09:00[Asia/Tokyo]", nil, "2025-01-02T12:04:05+0900[Asia/Tokyo]"},
+		{"2025-01-02T03:04:05.25-05:30[u-ca=gregory]", nil, "2025-01-02T03:04:05.25-0530[u-ca=gregory]"},
+		{"2025-01-02T03:04:05Z", nil, "2025-01-02T03:04:05Z"},
+		{"2025-01-02T03:04:05Z", []ixdtf.FormatOption{ixdtf.WithExplicitUTCOffset()}, "2025-01-02T03:04:05+0000"},
+		{"2025-01-02T03:04:05-00:00[UTC]", nil, "2025-01-02T03:04:05-0000[UTC]"},
+		// A numeric-offset annotation keeps the colon its grammar requires.
+		{"2025-01-02T12:04:05+09:00[+09:00]", nil, "2025-01-02T12:04:05+0900[+09:00]"},
+	}
+	for _, tc := range tests {
+		parsed, ext, err := ixdtf.Parse(tc.input, true)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", tc.input, err)
+		}
+		opts := append([]ixdtf.FormatOption{basic}, tc.opts...)
+		got, err := ixdtf.FormatNano(parsed, ext, opts...)
+		if err != nil || got != tc.want {
+			t.Fatalf("FormatNano(Parse(%q)) = %q, %v, want %q", tc.input, got, err, tc.want)
+		}
+
+		// A basic offset is outside the RFC 3339 grammar and parses back only
+		// with WithLenientSeparators; "Z" is unchanged.
+		if _, _, err := ixdtf.Parse(got, true); err == nil && got != tc.input {
+			t.Errorf("Parse(%q) without WithLenientSeparators expected an error, got nil", got)
+		}
+		reparsed, reext, err := ixdtf.Parse(got, true, ixdtf.WithLenientSeparators())
+		if err != nil {
+			t.Fatalf("Parse(%q) with WithLenientSeparators unexpected error: %v", got, err)
+		}
+		if !reparsed.Equal(parsed) || reext.UnknownOffset != ext.UnknownOffset {
+			t.Errorf("round trip of %q = %v (unknown offset %t), want %v (%t)",
+				got, reparsed, reext.UnknownOffset, parsed, ext.UnknownOffset)
+		}
+		if again, _ := ixdtf.FormatNano(reparsed, reext, opts...); again != got {
+			t.Errorf("FormatNano after round trip = %q, want %q", again, got)
+		}
+	}
+}
+
+func TestFormatWithTagsBeforeTimezone(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Location:         tokyo,
+		CriticalLocation: true,
+		Tags:             map[string]string{"u-ca": "gregory", "a-key": "1", "b-key": "2"},
+		Critical:         map[string]bool{"b-key": true},
+	})
+	tm := time.Date(2025, 1, 1, 9, 0, 0, 0, tokyo)
+	reversed := ixdtf.WithTagsBeforeTimezone()
+
+	tests := []struct {
+		name string
+		ext  *ixdtf.IXDTFExtensions
+		want string
+	}{
+		{"zone and tags", ext, "2025-01-01T09:00:00+09:00[a-key=1][!b-key=2][u-ca=gregory][!Asia/Tokyo]"},
+		{
+			"tags only",
+			ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": "gregory"}}),
+			"2025-01-01T09:00:00+09:00[u-ca=gregory][Asia/Tokyo]",
+		},
+		{"zone only", ixdtf.NewIXDTFExtensions(nil), "2025-01-01T09:00:00+09:00[Asia/Tokyo]"},
+	}
+	for _, tt := range tests {
+		got, err := ixdtf.Format(tm, tt.ext, reversed)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Format() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	// The reversed order is outside the grammar in both modes.
+	reordered := tests[1].want
+	for _, strict := range []bool{false, true} {
+		if _, _, err := ixdtf.Parse(reordered, strict); !errors.Is(err, ixdtf.ErrInvalidSuffix) {
+			t.Errorf("Parse(%q, %t) error = %v, want ErrInvalidSuffix", reordered, strict, err)
+		}
+	}
+}
+
+func TestMustFormat(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	good := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": "gregory"}})
+	bad := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"Bad_Key": "x"}})
+
+	const want = "2025-01-02T03:04:05Z[u-ca=gregory]"
+	if got := ixdtf.MustFormat(tm, good); got != want {
+		t.Errorf("MustFormat() = %q, want %q", got, want)
+	}
+	if got := ixdtf.FormatOrEmpty(tm, good); got != want {
+		t.Errorf("FormatOrEmpty() = %q, want %q", got, want)
+	}
+	if got := ixdtf.FormatOrEmpty(tm, bad); got != "" {
+		t.Errorf("FormatOrEmpty(invalid) = %q, want empty", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustFormat(invalid) did not panic")
+		}
+	}()
+	ixdtf.MustFormat(tm, bad)
+}
+
+func TestFormatChecked(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	tokyoExt := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Location: tokyo})
+	bad := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"Bad_Key": "x"}})
+	inTokyo := time.Date(2025, 1, 2, 12, 4, 5, 0, tokyo)
+	offTokyo := time.Date(2025, 1, 2, 12, 4, 5, 0, time.FixedZone("", 5*3600))
+
+	tests := []struct {
+		name    string
+		t       time.Time
+		ext     *ixdtf.IXDTFExtensions
+		strict  bool
+		opts    []ixdtf.FormatOption
+		want    string
+		wantErr error
+	}{
+		{"consistent", inTokyo, tokyoExt, true, nil, "2025-01-02T12:04:05+09:00[Asia/Tokyo]", nil},
+		{"offset mismatch, non-strict", offTokyo, tokyoExt, false, nil, "2025-01-02T12:04:05+05:00[Asia/Tokyo]", nil},
+		{"offset mismatch, strict", offTokyo, tokyoExt, true, nil, "", ixdtf.ErrTimezoneOffsetMismatch},
+		{"year beyond 9999", time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), nil, false, nil, "", &ixdtf.ParseError{}},
+		{
+			"basic offset",
+			inTokyo,
+			tokyoExt,
+			false,
+			[]ixdtf.FormatOption{ixdtf.WithBasicOffset()},
+			"",
+			&ixdtf.ParseError{},
+		},
+		{"format error", inTokyo, bad, false, nil, "", ixdtf.ErrInvalidExtension},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ixdtf.FormatChecked(tt.t, tt.ext, tt.strict, tt.opts...)
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil || got != tt.want {
+					t.Errorf("FormatChecked() = %q, %v, want %q", got, err, tt.want)
+				}
+			case *ixdtf.ParseError:
+				if !errors.As(err, &want) || got != "" {
+					t.Errorf("FormatChecked() = %q, %v, want a *ParseError", got, err)
+				}
+			default:
+				if !errors.Is(err, tt.wantErr) || got != "" {
+					t.Errorf("FormatChecked() = %q, %v, want %v", got, err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatFixedPrecision(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": "gregory"}})
+
+	tests := []struct {
+		name   string
+		format func(time.Time, *ixdtf.IXDTFExtensions, ...ixdtf.FormatOption) (string, error)
+		t      time.Time
+		opts   []ixdtf.FormatOption
+		want   string
+	}{
+		{
+			"FormatMilli pads whole seconds", ixdtf.FormatMilli,
+			time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), nil,
+			"2025-01-02T03:04:05.000Z[u-ca=gregory]",
+		},
+		{
+			"FormatMilli keeps trailing zeros", ixdtf.FormatMilli,
+			time.Date(2025, 1, 2, 3, 4, 5, 120000000, time.UTC), nil,
+			"2025-01-02T03:04:05.120Z[u-ca=gregory]",
+		},
+		{
+			"FormatMilli truncates", ixdtf.FormatMilli,
+			time.Date(2025, 1, 2, 3, 4, 5, 123999999, tokyo), nil,
+			"2025-01-02T03:04:05.123+09:00[Asia/Tokyo][u-ca=gregory]",
+		},
+		{
+			"FormatMicro pads", ixdtf.FormatMicro,
+			time.Date(2025, 1, 2, 3, 4, 5, 120000000, time.UTC), nil,
+			"2025-01-02T03:04:05.120000Z[u-ca=gregory]",
+		},
+		{
+			"FormatMicro truncates", ixdtf.FormatMicro,
+			time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC), nil,
+			"2025-01-02T03:04:05.123456Z[u-ca=gregory]",
+		},
+		{
+			"FormatMicro with explicit UTC offset", ixdtf.FormatMicro,
+			time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), []ixdtf.FormatOption{ixdtf.WithExplicitUTCOffset()},
+			"2025-01-02T03:04:05.000000+00:00[u-ca=gregory]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.format(tc.t, ext, tc.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("millisecond round trip", func(t *testing.T) {
+		t.Parallel()
+		want := time.Date(2025, 1, 2, 3, 4, 5, 7000000, tokyo)
+		got, err := ixdtf.FormatMilli(want, nil)
+		if err != nil {
+			t.Fatalf("FormatMilli() unexpected error: %v", err)
+		}
+		parsed, _, err := ixdtf.Parse(got, true)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", got, err)
+		}
+		if !parsed.Equal(want) {
+			t.Errorf("Parse(FormatMilli()) = %v, want %v", parsed, want)
+		}
+	})
+
+	t.Run("validates extensions", func(t *testing.T) {
+		t.Parallel()
+		bad := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"Bad_Key": "x"}})
+		for _, format := range []func(time.Time, *ixdtf.IXDTFExtensions, ...ixdtf.FormatOption) (string, error){
+			ixdtf.FormatMilli, ixdtf.FormatMicro,
+		} {
+			if _, err := format(time.Now(), bad); err == nil {
+				t.Error("expected an error for an invalid tag key, got nil")
+			}
+		}
+	})
+}
+
+func TestCriticalManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ext  *ixdtf.IXDTFExtensions
+		want string
+	}{
+		{
+			name: "nil extensions",
+			ext:  nil,
+			want: "",
+		},
+		{
+			name: "no critical tags",
+			ext: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+				Tags: map[string]string{"u-ca": "gregory"},
+			}),
+			want: "",
+		},
+		{
+			name: "multiple critical tags are sorted",
+			ext: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+				Tags: map[string]string{
+					"u-ca":     "gregory",
+					"t-format": "iso",
+					"a-elect":  "yes",
+				},
+				Critical: map[string]bool{"u-ca": true, "t-format": true},
+			}),
+			want: "t-format,u-ca",
+		},
+		{
+			name: "critical flag without a tag is not listed",
+			ext: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+				Tags:     map[string]string{"u-ca": "gregory"},
+				Critical: map[string]bool{"u-ca": true, "missing": true},
+			}),
+			want: "u-ca",
+		},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ixdtf.CriticalManifest(tc.ext); got != tc.want {
+				t.Errorf("CriticalManifest(%+v) = %q, want %q", tc.ext, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInLocation(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("America/New_York unavailable: %v", err)
+	}
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+		Tags:     map[string]string{"u-ca": "gregory"},
+		Critical: map[string]bool{"u-ca": true},
+	})
+
+	tests := []struct {
+		name    string
+		ext     *ixdtf.IXDTFExtensions
+		loc     *time.Location
+		want    string
+		wantErr error
+	}{
+		{
+			name: "named zone adds annotation and keeps tags",
+			ext:  ext,
+			loc:  newYork,
+			want: "2025-01-01T22:04:05-05:00[America/New_York][!u-ca=gregory]",
+		},
+		{
+			name: "nil extensions",
+			loc:  newYork,
+			want: "2025-01-01T22:04:05-05:00[America/New_York]",
+		},
+		{
+			name: "numeric-offset zone keeps its annotation",
+			ext:  ext,
+			loc:  time.FixedZone("+09:00", 9*3600),
+			want: "2025-01-02T12:04:05+09:00[+09:00][!u-ca=gregory]",
+		},
+		{
+			name: "unnamed fixed zone emits offset only",
+			ext:  ext,
+			loc:  time.FixedZone("", 9*3600),
+			want: "2025-01-02T12:04:05+09:00[!u-ca=gregory]",
+		},
+		{
+			name: "non-IANA fixed zone name is not emitted",
+			loc:  time.FixedZone("JST", 9*3600),
+			want: "2025-01-02T12:04:05+09:00",
+		},
+		{
+			name: "critical location carries over",
+			ext:  ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{CriticalLocation: true}),
+			loc:  newYork,
+			want: "2025-01-01T22:04:05-05:00[!America/New_York]",
+		},
+		{
+			name:    "critical location without an annotation",
+			ext:     ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{CriticalLocation: true}),
+			loc:     time.FixedZone("", 0),
+			wantErr: ixdtf.ErrCriticalExtension,
+		},
+		{
+			name:    "nil location",
+			ext:     ext,
+			wantErr: ixdtf.ErrInvalidTimezone,
+		},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ixdtf.InLocation(base, tc.ext, tc.loc)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("InLocation() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InLocation() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("InLocation() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatWithExplicitUTCOffset(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	utc := time.Date(2025, 1, 1, 0, 0, 0, 120000000, time.UTC)
+	ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": "gregory"}})
+
+	tests := []struct {
+		name   string
+		format func(time.Time, *ixdtf.IXDTFExtensions, ...ixdtf.FormatOption) (string, error)
+		t      time.Time
+		opts   []ixdtf.FormatOption
+		want   string
+	}{
+		{"default keeps Z", ixdtf.Format, utc, nil, "2025-01-01T00:00:00Z[u-ca=gregory]"},
+		{
+			"Format", ixdtf.Format, utc, []ixdtf.FormatOption{ixdtf.WithExplicitUTCOffset()},
+			"2025-01-01T00:00:00+00:00[u-ca=gregory]",
+		},
+		{
+			"FormatNano", ixdtf.FormatNano, utc, []ixdtf.FormatOption{ixdtf.WithExplicitUTCOffset()},
+			"2025-01-01T00:00:00.12+00:00[u-ca=gregory]",
+		},
+		{
+			"non-zero offset unchanged", ixdtf.Format, utc.In(tokyo),
+			[]ixdtf.FormatOption{ixdtf.WithExplicitUTCOffset()},
+			"2025-01-01T09:00:00+09:00[Asia/Tokyo][u-ca=gregory]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tc.format(tc.t, ext, tc.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+			parsed, _, err := ixdtf.Parse(got, false)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", got, err)
+			}
+			if !parsed.Equal(tc.t.Truncate(time.Second)) && !parsed.Equal(tc.t) {
+				t.Errorf("Parse(%q) = %v, want instant %v", got, parsed, tc.t)
+			}
+			if _, offset := parsed.Zone(); tc.t.Location() == time.UTC && offset != 0 {
+				t.Errorf("Parse(%q) offset = %d, want 0", got, offset)
+			}
+		})
+	}
+}
+
+func TestFormatWithEmitUTCBracket(t *testing.T) {
+	t.Parallel()
+
+	utc := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	emit := ixdtf.WithEmitUTCBracket()
+
+	t.Run("UTC", func(t *testing.T) {
+		t.Parallel()
+		got, err := ixdtf.Format(utc, nil, emit)
+		if err != nil {
+			t.Fatalf("Format() unexpected error: %v", err)
+		}
+		if want := "2025-01-01T00:00:00Z[UTC]"; got != want {
+			t.Errorf("Format() = %q, want %q", got, want)
+		}
+		if plain, _ := ixdtf.Format(utc, nil); plain != "2025-01-01T00:00:00Z" {
+			t.Errorf("Format() without option = %q, want no annotation", plain)
+		}
+	})
+
+	t.Run("critical UTC", func(t *testing.T) {
+		t.Parallel()
+		ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{CriticalLocation: true})
+		got, err := ixdtf.Format(utc, ext, emit)
+		if err != nil {
+			t.Fatalf("Format() unexpected error: %v", err)
+		}
+		if want := "2025-01-01T00:00:00Z[!UTC]"; got != want {
+			t.Errorf("Format() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("explicit location wins", func(t *testing.T) {
+		t.Parallel()
+		tokyo, _, _ := getTestTimezones()
+		ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Location: tokyo})
+		got, err := ixdtf.Format(utc.In(tokyo), ext, emit)
+		if err != nil {
+			t.Fatalf("Format() unexpected error: %v", err)
+		}
+		if want := "2025-01-01T09:00:00+09:00[Asia/Tokyo]"; got != want {
+			t.Errorf("Format() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Local resolves to the system zone", func(t *testing.T) {
+		t.Parallel()
+		got, err := ixdtf.Format(utc.In(time.Local), nil, emit)
+		if err != nil {
+			t.Fatalf("Format() unexpected error: %v", err)
+		}
+		if strings.Contains(got, "[Local]") {
+			t.Fatalf("Format() = %q, must not emit [Local]", got)
+		}
+		// Whatever zone is emitted must be consistent with the offset.
+		if _, _, err = ixdtf.Parse(got, true); err != nil {
+			t.Errorf("Parse(%q, true) unexpected error: %v", got, err)
+		}
+	})
+}
+
+func TestFormatWithStrictLocation(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo unavailable: %v", err)
+	}
+	tests := []struct {
+		name    string
+		t       time.Time
+		want    string
+		wantErr bool
+	}{
+		{"unknown own zone", base.In(time.FixedZone("No/SuchZone", 0)), "", true},
+		{"IANA own zone", base.In(tokyo), "2025-01-02T12:04:05+09:00[Asia/Tokyo]", false},
+		{
+			"numeric-offset own zone", base.In(time.FixedZone("+09:00", 9*3600)),
+			"2025-01-02T12:04:05+09:00[+09:00]", false,
+		},
+		{"unnamed own zone", base.In(time.FixedZone("", 3600)), "2025-01-02T04:04:05+01:00", false},
+		{"UTC", base, "2025-01-02T03:04:05Z", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ixdtf.Format(tc.t, nil, ixdtf.WithStrictLocation())
+			if tc.wantErr {
+				if !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+					t.Errorf("Format() error = %v, want %v", err, ixdtf.ErrInvalidTimezone)
+				}
+				if got, err := ixdtf.Format(tc.t, nil); err != nil || got == "" {
+					t.Errorf("Format() without the option = %q, %v, want output", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Format() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Format() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestFormatLocal does not call t.Parallel because it replaces time.Local,
+// which concurrently running tests would observe.
+func TestFormatLocal(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Europe/Berlin unavailable: %v", err)
+	}
+	systemLocal := time.Local
+
+	got, err := ixdtf.Format(time.Date(2025, 1, 1, 12, 0, 0, 0, systemLocal), nil)
+	if err != nil {
+		t.Fatalf("Format() unexpected error: %v", err)
+	}
+	if strings.Contains(got, "[Local]") {
+		t.Fatalf("Format() = %q, must not emit [Local]", got)
+	}
+	if _, _, err = ixdtf.Parse(got, true); err != nil {
+		t.Errorf("Parse(%q, true) unexpected error: %v", got, err)
+	}
+
+	time.Local = berlin
+	t.Cleanup(func() { time.Local = systemLocal })
+
+	got, err = ixdtf.Format(time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local), nil)
+	if err != nil {
+		t.Fatalf("Format() unexpected error: %v", err)
+	}
+	if want := "2025-01-01T12:00:00+01:00[Europe/Berlin]"; got != want {
+		t.Errorf("Format() with time.Local = Europe/Berlin = %q, want %q", got, want)
+	}
+}
+
+func TestStripExtensions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		strict  bool
+		want    string
+		wantErr bool
+	}{
+		{input: "2025-06-07T08:09:10+01:00[Europe/Paris][u-ca=gregory]", want: "2025-06-07T08:09:10+01:00"},
+		{input: "2025-01-01T00:00:00Z[Asia/Tokyo]", want: "2025-01-01T09:00:00+09:00"},
+		{input: "2025-01-01T00:00:00.500Z[u-ca=gregory]", want: "2025-01-01T00:00:00.5Z"},
+		{input: "2025-06-01T12:00:00+09:00[America/New_York]", want: "2025-06-01T12:00:00+09:00"},
+		{input: "2025-06-01T12:00:00+09:00[America/New_York]", strict: true, wantErr: true},
+		{input: "2025-01-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ixdtf.StripExtensions(tt.input, tt.strict)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("StripExtensions(%q, %t) = %q, want error", tt.input, tt.strict, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StripExtensions(%q, %t) unexpected error: %v", tt.input, tt.strict, err)
+			}
+			if got != tt.want {
+				t.Errorf("StripExtensions(%q, %t) = %q, want %q", tt.input, tt.strict, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatParsedOffsetIgnoresLocal does not call t.Parallel because it
+// replaces time.Local, which concurrently running tests would observe.
+func TestFormatParsedOffsetIgnoresLocal(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo unavailable: %v", err)
+	}
+	systemLocal := time.Local
+	time.Local = tokyo
+	t.Cleanup(func() { time.Local = systemLocal })
+
+	// An offset that matches the local zone must not pick up its name.
+	for _, input := range []string{"2025-01-02T03:04:05+09:00", "2025-01-02T03:04:05Z"} {
+		parsed, ext, err := ixdtf.Parse(input, false)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+		}
+		got, err := ixdtf.Format(parsed, ext)
+		if err != nil {
+			t.Fatalf("Format() unexpected error: %v", err)
+		}
+		if got != input {
+			t.Errorf("Format(Parse(%q)) = %q, want it unchanged", input, got)
+		}
+	}
+}
+
+func TestFormatUnknownOffset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-01-02T03:04:05-00:00", "2025-01-02T03:04:05-00:00"},
+		{"2025-01-02T03:04:05.5-00:00[u-ca=gregory]", "2025-01-02T03:04:05.5-00:00[u-ca=gregory]"},
+		{"2025-01-02T03:04:05-00:00[Asia/Tokyo]", "2025-01-02T03:04:05-00:00[Asia/Tokyo]"},
+		{"2025-01-02T03:04:05+00:00", "2025-01-02T03:04:05Z"},
+		{"2025-01-02T03:04:05Z", "2025-01-02T03:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			parsed, ext, err := ixdtf.Parse(tt.input, true)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if want := strings.Contains(tt.input, "-00:00"); ext.UnknownOffset != want {
+				t.Errorf("Parse(%q) UnknownOffset = %t, want %t", tt.input, ext.UnknownOffset, want)
+			}
+			got, err := ixdtf.FormatNano(parsed, ext)
+			if err != nil {
+				t.Fatalf("FormatNano() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatNano(Parse(%q)) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
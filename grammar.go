@@ -0,0 +1,32 @@
+package ixdtf
+
+import "github.com/8beeeaaat/ixdtf/abnf"
+
+// Abnf is a compiled RFC 9557 grammar rule; see the abnf package. It is
+// re-exported so callers importing only ixdtf can validate string fragments
+// with the patterns below and their Validate method.
+type Abnf = abnf.Abnf
+
+// RFC 9557 Section 4.1 grammar rules, re-exported from the abnf package under
+// the same names.
+//
+//nolint:gochecknoglobals // Re-exports of the abnf package's pattern variables.
+var (
+	// AbnfTimezoneName matches a bare time-zone name such as "Asia/Tokyo".
+	AbnfTimezoneName = abnf.AbnfTimezoneName
+	// AbnfTimezone matches a bracketed time-zone annotation such as
+	// "[!Asia/Tokyo]" or "[+09:00]".
+	AbnfTimezone = abnf.AbnfTimezone
+	// AbnfTimezoneTag is the former name of AbnfTimezone.
+	//
+	// Deprecated: use AbnfTimezone.
+	AbnfTimezoneTag = abnf.AbnfTimezone
+	// AbnfOffset matches a numeric offset such as "+09:00".
+	AbnfOffset = abnf.AbnfOffset
+	// AbnfSuffixKey matches a suffix-tag key such as "u-ca".
+	AbnfSuffixKey = abnf.AbnfSuffixKey
+	// AbnfSuffixValues matches a suffix-tag value such as "japanese".
+	AbnfSuffixValues = abnf.AbnfSuffixValues
+	// AbnfDateTimeExt matches a complete IXDTF string.
+	AbnfDateTimeExt = abnf.AbnfDateTimeExt
+)
@@ -0,0 +1,41 @@
+package ixdtf_test
+
+import (
+	"testing"
+
+	"github.com/8beeeaaat/ixdtf"
+	"github.com/8beeeaaat/ixdtf/abnf"
+)
+
+func TestAbnfReexports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pat     *ixdtf.Abnf
+		want    *abnf.Abnf
+		valid   string
+		invalid string
+	}{
+		{"TimezoneName", ixdtf.AbnfTimezoneName, abnf.AbnfTimezoneName, "America/Argentina/Salta", "[Asia/Tokyo]"},
+		{"Timezone", ixdtf.AbnfTimezone, abnf.AbnfTimezone, "[!Asia/Tokyo]", "Asia/Tokyo"},
+		{"Offset", ixdtf.AbnfOffset, abnf.AbnfOffset, "-03:30", "-0330"},
+		{"SuffixKey", ixdtf.AbnfSuffixKey, abnf.AbnfSuffixKey, "u-ca", "U-CA"},
+		{"SuffixValues", ixdtf.AbnfSuffixValues, abnf.AbnfSuffixValues, "gregory", "gre_gory"},
+		{"DateTimeExt", ixdtf.AbnfDateTimeExt, abnf.AbnfDateTimeExt, "2025-01-02T03:04:05Z", "2025-01-02"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if tc.pat != tc.want {
+				t.Fatalf("ixdtf.Abnf%s is not abnf.Abnf%s", tc.name, tc.name)
+			}
+			if err := tc.pat.Validate(tc.valid); err != nil {
+				t.Errorf("Validate(%q) unexpected error: %v", tc.valid, err)
+			}
+			if err := tc.pat.Validate(tc.invalid); err == nil {
+				t.Errorf("Validate(%q) expected an error, got nil", tc.invalid)
+			}
+		})
+	}
+}
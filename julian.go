@@ -0,0 +1,50 @@
+package ixdtf
+
+import "time"
+
+// Julian Day constants. The Julian Day Number of 1970-01-01, the civil day
+// starting at the Unix epoch, is 2440588; Julian days start at noon, so the
+// epoch itself falls on Julian Day 2440587.5.
+const (
+	unixEpochJulianDayNumber = 2440588
+	secondsPerDay            = 24 * 60 * 60
+)
+
+// JulianDay returns the Julian Day of the UTC instant of t: the number of
+// days, including the fraction from the time of day, elapsed since noon UTC
+// on 1 January 4713 BC in the proleptic Julian calendar (24 November 4714 BC
+// proleptic Gregorian). For example, 2000-01-01T12:00:00Z is 2451545.0. The
+// day uses UT without leap seconds, like Unix time. float64 resolves the
+// result to about 40 microseconds for present-day dates.
+func JulianDay(t time.Time) float64 {
+	sinceMidnight := time.Duration(floorMod(t.Unix(), secondsPerDay))*time.Second +
+		time.Duration(t.Nanosecond())
+	return float64(JulianDayNumber(t)) - 0.5 + float64(sinceMidnight)/float64(secondsPerDay*time.Second)
+}
+
+// JulianDayNumber returns the integer Julian Day Number of the UTC calendar
+// date of t, i.e. the Julian Day at noon UTC on that date. For example, every
+// instant of 2000-01-01 UTC gives 2451545. It is computed in integer
+// arithmetic, so it is exact over the whole time.Time range representable by
+// an int.
+func JulianDayNumber(t time.Time) int {
+	return int(floorDiv(t.Unix(), secondsPerDay) + unixEpochJulianDayNumber)
+}
+
+// floorDiv returns a / b rounded toward negative infinity, for b > 0.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+// floorMod returns a modulo b in the range [0, b), for b > 0.
+func floorMod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
@@ -0,0 +1,44 @@
+package ixdtf_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestJulianDay(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+
+	tests := []struct {
+		name    string
+		t       time.Time
+		wantJD  float64
+		wantJDN int
+	}{
+		{"J2000 epoch", time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 2451545.0, 2451545},
+		{"midnight starts the civil day", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), 2451544.5, 2451545},
+		{"last instant of the civil day", time.Date(2000, 1, 1, 23, 59, 59, 0, time.UTC), 2451545.49999, 2451545},
+		{"Unix epoch", time.Unix(0, 0), 2440587.5, 2440588},
+		{"fractional seconds", time.Date(2025, 1, 2, 6, 0, 0, 500000000, time.UTC), 2460677.75, 2460678},
+		{"zone does not matter", time.Date(2000, 1, 1, 21, 0, 0, 0, tokyo), 2451545.0, 2451545},
+		{"before the Unix epoch", time.Date(1969, 12, 31, 18, 0, 0, 0, time.UTC), 2440587.25, 2440587},
+		{"Gregorian reform", time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC), 2299160.5, 2299161},
+		{"start of the Julian period", time.Date(-4713, 11, 24, 12, 0, 0, 0, time.UTC), 0, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ixdtf.JulianDay(tc.t); math.Abs(got-tc.wantJD) > 1e-4 {
+				t.Errorf("JulianDay(%v) = %f, want %f", tc.t, got, tc.wantJD)
+			}
+			if got := ixdtf.JulianDayNumber(tc.t); got != tc.wantJDN {
+				t.Errorf("JulianDayNumber(%v) = %d, want %d", tc.t, got, tc.wantJDN)
+			}
+		})
+	}
+}
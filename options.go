@@ -0,0 +1,401 @@
+package ixdtf
+
+import "context"
+
+// Default bounds on the suffix checked by Validate before the ABNF pattern
+// runs; see WithMaxSuffixLength and WithMaxSuffixElements.
+const (
+	DefaultMaxSuffixLength   = 64 << 10
+	DefaultMaxSuffixElements = 1024
+)
+
+// ParseOption configures the behavior of Parse and Validate.
+type ParseOption func(*parseOptions)
+
+// parseOptions holds the settings for a single Parse or Validate call.
+type parseOptions struct {
+	strict            bool
+	loader            LocationLoader
+	maxSuffixLength   int
+	maxSuffixElements int
+	lowercaseKeys     bool
+	trace             *ParseTrace
+	abnfCrossCheck    bool
+	extensions        *ExtensionRegistry
+	numberingSystem   bool
+	foldZoneCase      bool
+	offsetConsistency bool
+	lenientSeparators bool
+	recordDroppedZone bool
+	maxTags           int
+	maxTagKeyLength   int
+	maxTagValueLength int
+	collectDuplicates bool
+	rejectDuplicates  bool
+	ctx               context.Context // set by ParseContext; nil otherwise
+	overPrecision     OverPrecisionMode
+	tolerateMismatch  bool // set by InspectConsistency; never escalate a mismatch
+	rejectMilitary    bool
+	expandedYears     bool
+}
+
+func newParseOptions(strict bool, opts []ParseOption) *parseOptions {
+	o := &parseOptions{
+		strict:            strict,
+		loader:            stdLocationLoader{},
+		maxSuffixLength:   DefaultMaxSuffixLength,
+		maxSuffixElements: DefaultMaxSuffixElements,
+		extensions:        defaultExtensionRegistry,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithStrict enables strict mode for functions that take no strict
+// argument, such as ParseResultOf. It has the same effect as passing
+// strict=true to Parse or Validate.
+func WithStrict() ParseOption {
+	return func(o *parseOptions) {
+		o.strict = true
+	}
+}
+
+// WithLocationLoader sets the loader used to resolve IANA time-zone names,
+// for example one backed by an embedded tzdata copy when the system zoneinfo
+// is unavailable. A nil loader restores the default, which wraps
+// time.LoadLocation. The package-level cache holds only zones from the
+// default loader: a custom loader is consulted on every lookup, so two
+// loaders can resolve the same name differently, and one that loads slowly
+// should cache on its own.
+func WithLocationLoader(l LocationLoader) ParseOption {
+	return func(o *parseOptions) {
+		if l == nil {
+			l = stdLocationLoader{}
+		}
+		o.loader = l
+	}
+}
+
+// WithMaxSuffixLength bounds the length in bytes of the bracketed suffix that
+// Validate accepts; longer suffixes fail with ErrSuffixTooLong before any
+// pattern matching. A value of zero or less removes the bound. The default is
+// DefaultMaxSuffixLength.
+func WithMaxSuffixLength(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxSuffixLength = n
+	}
+}
+
+// WithMaxSuffixElements bounds the number of bracket groups in the suffix that
+// Validate accepts; more groups fail with ErrTooManyTags before any pattern
+// matching. A value of zero or less removes the bound. The default is
+// DefaultMaxSuffixElements.
+func WithMaxSuffixElements(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxSuffixElements = n
+	}
+}
+
+// WithLowercaseKeys makes a non-strict parse ASCII-lowercase suffix keys
+// before validating them, so a non-compliant "[U-CA=gregory]" is read as
+// "u-ca". RFC 9557 Section 4.1 only permits lower-case keys, so strict mode
+// ignores this option and keeps rejecting upper-case keys. When a key is
+// rewritten, IXDTFExtensions.KeysLowercased is set. Validate still checks
+// the original string against the RFC 9557 ABNF, so it rejects such input
+// even with this option.
+func WithLowercaseKeys() ParseOption {
+	return func(o *parseOptions) {
+		o.lowercaseKeys = true
+	}
+}
+
+// WithABNFCrossCheck makes Validate also match the input against the
+// abnf.AbnfDateTimeExt regular expression after the built-in grammar
+// scanner accepts it. The two agree by construction, so this only serves
+// as a debugging aid and roughly doubles the cost of the grammar check.
+func WithABNFCrossCheck() ParseOption {
+	return func(o *parseOptions) {
+		o.abnfCrossCheck = true
+	}
+}
+
+// WithCaseInsensitiveTimezone makes a time-zone annotation that does not
+// resolve as written, such as "[asia/tokyo]" or "[AMERICA/NEW_YORK]", match
+// an IANA zone case-insensitively. On a match, IXDTFExtensions.Location is
+// the canonically cased zone ("Asia/Tokyo"). Irregular names that are not
+// simply capitalized words (e.g. "UTC", "EST5EDT") are found through the
+// system zoneinfo directory and are not matched when it is unavailable.
+func WithCaseInsensitiveTimezone() ParseOption {
+	return func(o *parseOptions) {
+		o.foldZoneCase = true
+	}
+}
+
+// WithOffsetConsistencyCheck makes a non-strict Parse or Validate fail with
+// ErrTimezoneOffsetMismatch when the time-zone annotation names a zone that
+// loads but whose offset at that instant disagrees with the RFC 3339 offset,
+// as in "+09:00[America/New_York]". Everything else stays lenient: an unknown
+// zone is still ignored and unknown critical tags are still tolerated. "Z"
+// and "-00:00" remain consistent with any zone (RFC 9557 Section 2.2).
+func WithOffsetConsistencyCheck() ParseOption {
+	return func(o *parseOptions) {
+		o.offsetConsistency = true
+	}
+}
+
+// WithValidateNumberingSystem makes Parse and Validate check "u-nu" values
+// against NumberingSystems, failing with ErrUnknownNumberingSystem for an
+// unrecognized identifier such as "latin". The check applies in both modes
+// and to elective as well as critical tags.
+func WithValidateNumberingSystem() ParseOption {
+	return func(o *parseOptions) {
+		o.numberingSystem = true
+	}
+}
+
+// FormatOption configures the behavior of Format and FormatNano.
+type FormatOption func(*formatOptions)
+
+// formatOptions holds the settings for a single Format or FormatNano call.
+type formatOptions struct {
+	explicitUTCOffset    bool
+	emitUTCBracket       bool
+	strictLocation       bool
+	preserveSourceOffset bool
+	preserveZuluForm     bool
+	basicOffset          bool
+	tagsBeforeTimezone   bool
+}
+
+func newFormatOptions(opts []FormatOption) *formatOptions {
+	o := &formatOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithExplicitUTCOffset makes Format and FormatNano write a zero offset as
+// "+00:00" instead of "Z", for consumers that do not accept the shorthand.
+// The instant is unchanged, and Parse still reads "+00:00" as UTC. Note that
+// RFC 9557 Section 2.2 gives "Z" the meaning of an unknown local offset, while
+// "+00:00" asserts a zero offset, so a time-zone annotation other than a
+// zero-offset zone becomes inconsistent with it.
+func WithExplicitUTCOffset() FormatOption {
+	return func(o *formatOptions) {
+		o.explicitUTCOffset = true
+	}
+}
+
+// WithEmitUTCBracket makes Format and FormatNano annotate a timestamp whose
+// own zone is UTC with "[UTC]" when ext.Location is unset, signaling that the
+// zone is intentional.
+//
+// A timestamp in time.Local is always annotated with the IANA name of the
+// system zone instead of the invalid "[Local]", with or without this option:
+// the name comes from the TZ environment variable or, failing that, the
+// /etc/localtime symlink, and is only used when that zone loads and agrees
+// with the timestamp's offset. When no such name is found, no annotation is
+// emitted.
+func WithEmitUTCBracket() FormatOption {
+	return func(o *formatOptions) {
+		o.emitUTCBracket = true
+	}
+}
+
+// WithStrictLocation makes Format and FormatNano fail with an error matching
+// ErrInvalidTimezone, before emitting anything, when the zone they would
+// annotate does not load. ext.Location is always checked this way; the
+// option extends the check to the timestamp's own zone used when
+// ext.Location is unset, so a time in time.FixedZone("No/SuchZone", 0) is
+// rejected instead of producing "[No/SuchZone]", which strict Parse would
+// refuse. Unnamed zones, UTC, and numeric-offset names such as "+09:00" are
+// unaffected.
+func WithStrictLocation() FormatOption {
+	return func(o *formatOptions) {
+		o.strictLocation = true
+	}
+}
+
+// WithPreserveSourceOffset makes Format and FormatNano write the instant at
+// ext.Offset, the offset stated by the parsed string, instead of the offset
+// of the timestamp's location, so an unmodified parse result is re-emitted
+// with its original offset even if the zone would compute another one, e.g.
+// "2025-01-02T03:04:05Z[Asia/Tokyo]" stays in "Z" rather than becoming
+// "+09:00". Extensions without an Offset, such as those not produced by
+// parsing, and a "-00:00" source are unaffected.
+func WithPreserveSourceOffset() FormatOption {
+	return func(o *formatOptions) {
+		o.preserveSourceOffset = true
+	}
+}
+
+// WithPreserveZuluForm makes Format and FormatNano write the instant in UTC
+// with the "Z" designator when ext.ZuluOffset records that the parsed string
+// used it. Parse applies the zone of "2025-01-02T03:04:05Z[Asia/Tokyo]", so
+// Format would otherwise write "2025-01-02T12:04:05+09:00[Asia/Tokyo]"; with
+// this option the input is reproduced. Both name the same instant: "Z" is an
+// unknown local offset and consistent with any zone (RFC 9557 Section 2.2).
+func WithPreserveZuluForm() FormatOption {
+	return func(o *formatOptions) {
+		o.preserveZuluForm = true
+	}
+}
+
+// WithBasicOffset makes Format and FormatNano write a numeric offset in the
+// ISO 8601 basic form "+0900" instead of "+09:00". RFC 3339 and the
+// time-numoffset rule of RFC 9557 Section 4.1 require the colon, so the
+// output is not IXDTF: Parse and Validate reject it unless
+// WithLenientSeparators is passed. A zero offset is still written as "Z",
+// or as "+0000" with WithExplicitUTCOffset, and a numeric-offset time-zone
+// annotation such as "[+09:00]" keeps its colon.
+func WithBasicOffset() FormatOption {
+	return func(o *formatOptions) {
+		o.basicOffset = true
+	}
+}
+
+// WithTagsBeforeTimezone makes Format and FormatNano write the suffix tags
+// before the time-zone annotation, as in
+// "2025-01-01T00:00:00Z[u-ca=gregory][Asia/Tokyo]", for a consumer that
+// expects that order. The output is not IXDTF: the RFC 9557 Section 4.1
+// grammar places the time-zone annotation first, and Parse and Validate
+// reject the reversed order in both modes. Only the zone moves; the tags
+// keep their sorted order.
+func WithTagsBeforeTimezone() FormatOption {
+	return func(o *formatOptions) {
+		o.tagsBeforeTimezone = true
+	}
+}
+
+// WithLenientSeparators makes Parse and Validate accept a space or a
+// lower-case "t" between the date and the time, and a lower-case "z" offset,
+// as in "2025-01-02 03:04:05Z" or "2025-01-02t03:04:05z". RFC 3339
+// Section 5.6 permits both, but time.RFC3339 and the default grammar check
+// only accept "T" and "Z". It also accepts an offset without the colon, as in
+// "2025-01-02T03:04:05+0900", which is what WithBasicOffset writes. The RFC
+// 3339 portion is normalized before parsing; the suffix is unaffected.
+func WithLenientSeparators() ParseOption {
+	return func(o *parseOptions) {
+		o.lenientSeparators = true
+	}
+}
+
+// WithRecordDroppedZone makes a non-strict Parse that ignores an unknown
+// time-zone annotation, such as "[Mars/Olympus]", record its name instead of
+// discarding it: IXDTFExtensions.TimeZoneName returns the name while Location
+// stays nil and ZoneResolved stays false, so the instant keeps the RFC 3339
+// offset. Strict mode and critical annotations still fail on unknown zones.
+func WithRecordDroppedZone() ParseOption {
+	return func(o *parseOptions) {
+		o.recordDroppedZone = true
+	}
+}
+
+// WithMaxTags bounds the number of key=value suffix tags that Parse and
+// Validate accept, counting duplicates; the first tag beyond n fails with
+// ErrTooManyTags before it is stored, bounding the Tags and Critical maps on
+// untrusted input. A value of zero or less, the default, removes the bound.
+// Unlike WithMaxSuffixElements, the time-zone annotation is not counted.
+func WithMaxTags(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxTags = n
+	}
+}
+
+// WithMaxTagKeyLength bounds the length in bytes of a suffix tag key that
+// Parse and Validate accept; a longer key fails with ErrTagTooLong before it
+// is validated or stored. A value of zero or less, the default, removes the
+// bound.
+func WithMaxTagKeyLength(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxTagKeyLength = n
+	}
+}
+
+// WithMaxTagValueLength bounds the length in bytes of a suffix tag value,
+// including the "-" separators between its parts, as WithMaxTagKeyLength
+// does for keys. A value of zero or less, the default, removes the bound.
+func WithMaxTagValueLength(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxTagValueLength = n
+	}
+}
+
+// WithCollectDuplicates makes Parse record each elective suffix tag dropped
+// because an earlier tag had the same key in IXDTFExtensions.Duplicates, so a
+// producer bug can be logged or rejected. The first occurrence still wins,
+// and a duplicate involving a critical flag is still an error.
+func WithCollectDuplicates() ParseOption {
+	return func(o *parseOptions) {
+		o.collectDuplicates = true
+	}
+}
+
+// WithRejectDuplicates makes Parse and Validate fail with ErrDuplicateKey
+// when a suffix key appears more than once, as in
+// "[u-ca=japanese][u-ca=gregory]", instead of keeping the first occurrence.
+// It applies in both modes. A duplicate involving a critical flag keeps
+// failing with ErrCriticalExtension, as RFC 9557 Section 3.3 requires.
+func WithRejectDuplicates() ParseOption {
+	return func(o *parseOptions) {
+		o.rejectDuplicates = true
+	}
+}
+
+// OverPrecisionMode selects how Parse reduces fractional seconds with more
+// than nine digits to the nanosecond resolution of time.Time.
+type OverPrecisionMode int
+
+const (
+	// OverPrecisionTruncate drops the digits beyond the ninth, as time.Parse
+	// does. It is the default.
+	OverPrecisionTruncate OverPrecisionMode = iota
+
+	// OverPrecisionRound rounds to the nearest nanosecond using
+	// round-half-to-even, so "05.1234567895Z" becomes 5.123456790s and
+	// "05.1234567885Z" becomes 5.123456788s.
+	OverPrecisionRound
+)
+
+// WithOverPrecision sets how Parse handles fractional seconds with more than
+// nine digits; see OverPrecisionMode. Inputs with at most nine digits are
+// unaffected.
+func WithOverPrecision(mode OverPrecisionMode) ParseOption {
+	return func(o *parseOptions) {
+		o.overPrecision = mode
+	}
+}
+
+// WithRejectMilitaryZones makes Parse and Validate fail with
+// ErrInvalidTimezone on a single-letter time-zone annotation such as "[Z]"
+// or "[A]", the military zone letters some feeds send. Such names match the
+// time-zone-name grammar but are not IANA zones, so a strict parse already
+// rejects them while a non-strict parse would silently drop them; with this
+// option the non-strict parse fails too. Any single letter is rejected,
+// whether or not it is a military zone letter.
+func WithRejectMilitaryZones() ParseOption {
+	return func(o *parseOptions) {
+		o.rejectMilitary = true
+	}
+}
+
+// WithExpandedYears makes Parse and Validate accept an ISO 8601 expanded
+// year of a sign and six digits, as in "+012025-01-02T03:04:05Z" or
+// "-000044-03-15T12:00:00Z", for dates outside years 0000 through 9999.
+// RFC 3339 and the date-time-ext grammar of RFC 9557 only allow four-digit
+// years, so the input is not IXDTF; the year is checked here and the rest of
+// the string against the usual grammar. Years are numbered as in ISO 8601 and
+// time.Time, with year 0 before year 1, so "-000044" is 45 BCE. Format does
+// not write expanded years.
+func WithExpandedYears() ParseOption {
+	return func(o *parseOptions) {
+		o.expandedYears = true
+	}
+}
@@ -0,0 +1,562 @@
+package ixdtf_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+// mapLocationLoader resolves zone names from a fixed map, standing in for an
+// embedded tzdata loader in environments without the system zoneinfo.
+type mapLocationLoader map[string]*time.Location
+
+func (m mapLocationLoader) Load(name string) (*time.Location, error) {
+	if loc, ok := m[name]; ok {
+		return loc, nil
+	}
+	return nil, errors.New("unknown time zone " + name)
+}
+
+func TestWithLocationLoader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom loader resolves a zone unknown to the stdlib", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00+03:00[Loader/Resolved]"
+		loader := mapLocationLoader{"Loader/Resolved": time.FixedZone("Loader/Resolved", 3*3600)}
+
+		got, ext, err := ixdtf.Parse(input, true, ixdtf.WithLocationLoader(loader))
+		if err != nil {
+			t.Fatalf("Parse(%q, true) unexpected error: %v", input, err)
+		}
+		if ext.Location == nil || ext.Location.String() != "Loader/Resolved" {
+			t.Fatalf("Parse(%q, true) location = %v, want Loader/Resolved", input, ext.Location)
+		}
+		if _, off := got.Zone(); off != 3*3600 {
+			t.Errorf("Parse(%q, true) offset = %d, want %d", input, off, 3*3600)
+		}
+		if err = ixdtf.Validate(input, true, ixdtf.WithLocationLoader(loader)); err != nil {
+			t.Errorf("Validate(%q, true) unexpected error: %v", input, err)
+		}
+	})
+
+	t.Run("custom loader failure is an unknown zone", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00Z[Loader/Missing]"
+		loader := mapLocationLoader{}
+
+		_, _, err := ixdtf.Parse(input, true, ixdtf.WithLocationLoader(loader))
+		if !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+			t.Fatalf("Parse(%q, true) error = %v, want ErrInvalidTimezone", input, err)
+		}
+		_, ext, err := ixdtf.Parse(input, false, ixdtf.WithLocationLoader(loader))
+		if err != nil {
+			t.Fatalf("Parse(%q, false) unexpected error: %v", input, err)
+		}
+		if ext.Location != nil {
+			t.Errorf("Parse(%q, false) location = %v, want nil", input, ext.Location)
+		}
+	})
+
+	t.Run("loaders do not share resolved zones", func(t *testing.T) {
+		t.Parallel()
+		const name = "Loader/Sequence"
+		first := mapLocationLoader{name: time.FixedZone(name, 3*3600)}
+		second := mapLocationLoader{name: time.FixedZone(name, 5*3600)}
+
+		for _, tt := range []struct {
+			loader mapLocationLoader
+			offset int
+		}{{first, 3 * 3600}, {second, 5 * 3600}, {first, 3 * 3600}} {
+			input := time.Date(2025, 1, 1, 0, 0, 0, 0, tt.loader[name]).Format(time.RFC3339) + "[" + name + "]"
+			got, _, err := ixdtf.Parse(input, true, ixdtf.WithLocationLoader(tt.loader))
+			if err != nil {
+				t.Fatalf("Parse(%q, true) unexpected error: %v", input, err)
+			}
+			if _, off := got.Zone(); off != tt.offset {
+				t.Errorf("Parse(%q, true) offset = %d, want %d", input, off, tt.offset)
+			}
+		}
+		// Nor does the default loader see a zone only a custom one knows.
+		_, _, err := ixdtf.Parse("2025-01-01T00:00:00+03:00["+name+"]", true)
+		if !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+			t.Errorf("Parse with the default loader error = %v, want ErrInvalidTimezone", err)
+		}
+	})
+
+	t.Run("nil loader keeps the default", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00+09:00[Asia/Tokyo]"
+		if _, _, err := ixdtf.Parse(input, true, ixdtf.WithLocationLoader(nil)); err != nil {
+			t.Fatalf("Parse(%q, true) unexpected error: %v", input, err)
+		}
+	})
+}
+
+func TestWithLowercaseKeys(t *testing.T) {
+	t.Parallel()
+	const input = "2025-03-04T05:06:07Z[U-CA=gregory]"
+
+	t.Run("non-strict option lowercases the key", func(t *testing.T) {
+		t.Parallel()
+		_, ext, err := ixdtf.Parse(input, false, ixdtf.WithLowercaseKeys())
+		if err != nil {
+			t.Fatalf("Parse(%q, false) unexpected error: %v", input, err)
+		}
+		if got := ext.Tags[ixdtf.ExtensionUnicodeCalendar]; got != "gregory" {
+			t.Errorf("Parse(%q, false) u-ca = %q, want %q", input, got, "gregory")
+		}
+		if !ext.KeysLowercased {
+			t.Errorf("Parse(%q, false) expected KeysLowercased to be true", input)
+		}
+	})
+
+	t.Run("lower-case keys are not reported as normalized", func(t *testing.T) {
+		t.Parallel()
+		const lower = "2025-03-04T05:06:07Z[u-ca=gregory]"
+		_, ext, err := ixdtf.Parse(lower, false, ixdtf.WithLowercaseKeys())
+		if err != nil {
+			t.Fatalf("Parse(%q, false) unexpected error: %v", lower, err)
+		}
+		if ext.KeysLowercased {
+			t.Errorf("Parse(%q, false) expected KeysLowercased to be false", lower)
+		}
+	})
+
+	t.Run("default mode rejects upper-case keys", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := ixdtf.Parse(input, false)
+		checkParseError(t, err, input, false, "invalid extension format")
+	})
+
+	t.Run("strict mode ignores the option", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := ixdtf.Parse(input, true, ixdtf.WithLowercaseKeys())
+		checkParseError(t, err, input, true, "invalid extension format")
+	})
+}
+
+func TestWithCaseInsensitiveTimezone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-01-01T00:00:00+09:00[asia/tokyo]", "Asia/Tokyo"},
+		{"2025-01-01T00:00:00-05:00[AMERICA/NEW_YORK]", "America/New_York"},
+		{"2025-01-01T00:00:00-05:00[!america/new_york][u-ca=gregory]", "America/New_York"},
+		{"2025-01-01T00:00:00+09:00[Asia/Tokyo]", "Asia/Tokyo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			for _, strict := range []bool{false, true} {
+				_, ext, err := ixdtf.Parse(tt.input, strict, ixdtf.WithCaseInsensitiveTimezone())
+				if err != nil {
+					t.Fatalf("Parse(%q, %t) unexpected error: %v", tt.input, strict, err)
+				}
+				if ext.Location == nil || ext.Location.String() != tt.want {
+					t.Errorf("Parse(%q, %t) Location = %v, want %s", tt.input, strict, ext.Location, tt.want)
+				}
+			}
+		})
+	}
+
+	t.Run("default is case-sensitive", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00+09:00[asia/tokyo]"
+		if _, _, err := ixdtf.Parse(input, true); err == nil {
+			t.Errorf("Parse(%q, true) expected error without the option", input)
+		}
+		_, ext, err := ixdtf.Parse(input, false)
+		if err != nil || ext.Location != nil {
+			t.Errorf("Parse(%q, false) = (%+v, %v), want the zone ignored", input, ext, err)
+		}
+	})
+
+	t.Run("unknown zone still fails", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00Z[foo/bar]"
+		if _, _, err := ixdtf.Parse(input, true, ixdtf.WithCaseInsensitiveTimezone()); err == nil {
+			t.Errorf("Parse(%q, true) expected error", input)
+		}
+	})
+}
+
+func TestWithOffsetConsistencyCheck(t *testing.T) {
+	t.Parallel()
+
+	check := ixdtf.WithOffsetConsistencyCheck()
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"mismatch", "2025-06-01T12:00:00+09:00[America/New_York]", ixdtf.ErrTimezoneOffsetMismatch},
+		{"consistent", "2025-06-01T12:00:00-04:00[America/New_York]", nil},
+		{"unknown local offset", "2025-06-01T12:00:00Z[America/New_York]", nil},
+		{"unknown zone stays lenient", "2025-06-01T12:00:00+09:00[Foo/Bar]", nil},
+		{"unknown critical tag stays lenient", "2025-06-01T12:00:00Z[!t-unknown=x]", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, parseErr := ixdtf.Parse(tt.input, false, check)
+			validateErr := ixdtf.Validate(tt.input, false, check)
+			for fn, err := range map[string]error{"Parse": parseErr, "Validate": validateErr} {
+				if tt.wantErr == nil && err != nil {
+					t.Errorf("%s(%q) unexpected error: %v", fn, tt.input, err)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("%s(%q) error = %v, want %v", fn, tt.input, err, tt.wantErr)
+				}
+			}
+		})
+	}
+
+	if _, _, err := ixdtf.Parse(tests[0].input, false); err != nil {
+		t.Errorf("Parse(%q) without the option unexpected error: %v", tests[0].input, err)
+	}
+}
+
+func TestWithLenientSeparators(t *testing.T) {
+	t.Parallel()
+
+	lenient := ixdtf.WithLenientSeparators()
+	tests := []struct {
+		input    string
+		want     time.Time
+		wantZone string
+	}{
+		{"2025-01-02 03:04:05Z", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), ""},
+		{"2025-01-02t03:04:05z[Asia/Tokyo]", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), "Asia/Tokyo"},
+		{"2025-01-02 03:04:05.5+09:00[u-ca=gregory]", time.Date(2025, 1, 1, 18, 4, 5, 5e8, time.UTC), ""},
+		{"2025-01-02T03:04:05-0530[u-ca=gregory]", time.Date(2025, 1, 2, 8, 34, 5, 0, time.UTC), ""},
+		{"2025-01-02T12:04:05+0900[Asia/Tokyo]", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), "Asia/Tokyo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if _, _, err := ixdtf.Parse(tt.input, true); err == nil {
+				t.Errorf("Parse(%q) without the option expected an error, got nil", tt.input)
+			}
+			if err := ixdtf.Validate(tt.input, true); err == nil {
+				t.Errorf("Validate(%q) without the option expected an error, got nil", tt.input)
+			}
+
+			got, ext, err := ixdtf.Parse(tt.input, true, lenient)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if tt.wantZone != "" && (ext.Location == nil || ext.Location.String() != tt.wantZone) {
+				t.Errorf("Parse(%q) Location = %v, want %s", tt.input, ext.Location, tt.wantZone)
+			}
+			if err := ixdtf.Validate(tt.input, true, lenient); err != nil {
+				t.Errorf("Validate(%q) unexpected error: %v", tt.input, err)
+			}
+		})
+	}
+
+	if _, _, err := ixdtf.Parse("2025-01-02_03:04:05Z", false, lenient); err == nil {
+		t.Error("Parse with an underscore separator expected an error, got nil")
+	}
+}
+
+func TestWithRecordDroppedZone(t *testing.T) {
+	t.Parallel()
+
+	record := ixdtf.WithRecordDroppedZone()
+	const input = "2025-01-02T03:04:05+09:00[Mars/Olympus][u-ca=gregory]"
+
+	got, ext, err := ixdtf.Parse(input, false, record)
+	if err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+	}
+	if ext.Location != nil || ext.ZoneResolved {
+		t.Errorf("Location = %v, ZoneResolved = %t, want nil, false", ext.Location, ext.ZoneResolved)
+	}
+	if name := ext.TimeZoneName(); name != "Mars/Olympus" {
+		t.Errorf("TimeZoneName() = %q, want %q", name, "Mars/Olympus")
+	}
+	if _, offset := got.Zone(); offset != 9*3600 {
+		t.Errorf("offset = %d, want %d", offset, 9*3600)
+	}
+	if out, _ := ixdtf.Format(got, ext); out != "2025-01-02T03:04:05+09:00[u-ca=gregory]" {
+		t.Errorf("Format() = %q, want the dropped zone omitted", out)
+	}
+
+	if _, ext, _ = ixdtf.Parse(input, false); ext.TimeZoneName() != "" {
+		t.Errorf("without the option TimeZoneName() = %q, want empty", ext.TimeZoneName())
+	}
+	if _, ext, _ = ixdtf.Parse("2025-01-02T03:04:05+09:00[Asia/Tokyo]", false, record); !ext.ZoneResolved {
+		t.Error("ZoneResolved = false for a known zone, want true")
+	}
+	for _, strictInput := range []string{input, "2025-01-02T03:04:05+09:00[!Mars/Olympus]"} {
+		_, _, err := ixdtf.Parse(strictInput, strictInput == input, record)
+		if !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+			t.Errorf("Parse(%q) error = %v, want %v", strictInput, err, ixdtf.ErrInvalidTimezone)
+		}
+	}
+}
+
+func TestWithMaxTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		max     int
+		wantErr bool
+	}{
+		{"at the limit", "2025-01-02T03:04:05Z[Asia/Tokyo][a=1][b=2]", 2, false},
+		{"over the limit", "2025-01-02T03:04:05Z[a=1][b=2][c=3]", 2, true},
+		{"duplicates count", "2025-01-02T03:04:05Z[a=1][a=2][a=3]", 2, true},
+		{"zero is unlimited", "2025-01-02T03:04:05Z[a=1][b=2][c=3]", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opt := ixdtf.WithMaxTags(tt.max)
+			_, _, parseErr := ixdtf.Parse(tt.input, false, opt)
+			validateErr := ixdtf.Validate(tt.input, false, opt)
+			for fn, err := range map[string]error{"Parse": parseErr, "Validate": validateErr} {
+				if tt.wantErr && !errors.Is(err, ixdtf.ErrTooManyTags) {
+					t.Errorf("%s(%q) error = %v, want %v", fn, tt.input, err, ixdtf.ErrTooManyTags)
+				}
+				if !tt.wantErr && err != nil {
+					t.Errorf("%s(%q) unexpected error: %v", fn, tt.input, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWithMaxTagLength(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		opt     ixdtf.ParseOption
+		wantErr bool
+	}{
+		{"key at the limit", strings.Repeat("k", n), "v", ixdtf.WithMaxTagKeyLength(n), false},
+		{"key over the limit", strings.Repeat("k", n+1), "v", ixdtf.WithMaxTagKeyLength(n), true},
+		{"value at the limit", "k", strings.Repeat("v", n), ixdtf.WithMaxTagValueLength(n), false},
+		{"value over the limit", "k", strings.Repeat("v", n+1), ixdtf.WithMaxTagValueLength(n), true},
+		{"multi-part value over the limit", "k", "abcd-efgh", ixdtf.WithMaxTagValueLength(n), true},
+		{"key limit ignores values", "k", strings.Repeat("v", n+1), ixdtf.WithMaxTagKeyLength(n), false},
+		{"zero is unlimited", strings.Repeat("k", 1000), strings.Repeat("v", 8), ixdtf.WithMaxTagKeyLength(0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			input := "2025-01-02T03:04:05Z[" + tt.key + "=" + tt.value + "]"
+			_, _, parseErr := ixdtf.Parse(input, false, tt.opt)
+			validateErr := ixdtf.Validate(input, false, tt.opt)
+			for fn, err := range map[string]error{"Parse": parseErr, "Validate": validateErr} {
+				if tt.wantErr && !errors.Is(err, ixdtf.ErrTagTooLong) {
+					t.Errorf("%s() error = %v, want %v", fn, err, ixdtf.ErrTagTooLong)
+				}
+				if !tt.wantErr && err != nil {
+					t.Errorf("%s() unexpected error: %v", fn, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWithCollectDuplicates(t *testing.T) {
+	t.Parallel()
+
+	collect := ixdtf.WithCollectDuplicates()
+	const input = "2025-01-02T03:04:05Z[key=one][key=two][u-ca=gregory][key=three]"
+	_, ext, err := ixdtf.Parse(input, false, collect)
+	if err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+	}
+	if got := ext.Tags["key"]; got != "one" {
+		t.Errorf("Tags[key] = %q, want the first occurrence %q", got, "one")
+	}
+	want := []ixdtf.Tag{{Key: "key", Value: "two"}, {Key: "key", Value: "three"}}
+	if !slices.Equal(ext.Duplicates, want) {
+		t.Errorf("Duplicates = %v, want %v", ext.Duplicates, want)
+	}
+
+	if _, ext, _ = ixdtf.Parse(input, false); ext.Duplicates != nil {
+		t.Errorf("without the option Duplicates = %v, want nil", ext.Duplicates)
+	}
+	if _, _, err = ixdtf.Parse("2025-01-02T03:04:05Z[key=one][!key=two]", false, collect); err == nil {
+		t.Error("Parse with a critical duplicate expected an error, got nil")
+	}
+}
+
+func TestWithRejectDuplicates(t *testing.T) {
+	t.Parallel()
+
+	reject := ixdtf.WithRejectDuplicates()
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"duplicate", "2025-01-02T03:04:05Z[u-ca=japanese][u-ca=gregory]", ixdtf.ErrDuplicateKey},
+		{"identical duplicate", "2025-01-02T03:04:05Z[t-a=1][t-b=2][t-a=1]", ixdtf.ErrDuplicateKey},
+		{"critical duplicate", "2025-01-02T03:04:05Z[u-ca=japanese][!u-ca=gregory]", ixdtf.ErrCriticalExtension},
+		{"distinct keys", "2025-01-02T03:04:05Z[u-ca=japanese][u-nu=latn]", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, strict := range []bool{false, true} {
+				_, _, parseErr := ixdtf.Parse(tt.input, strict, reject)
+				validateErr := ixdtf.Validate(tt.input, strict, reject)
+				for fn, err := range map[string]error{"Parse": parseErr, "Validate": validateErr} {
+					if tt.wantErr == nil && err != nil {
+						t.Errorf("%s(%q, %t) unexpected error: %v", fn, tt.input, strict, err)
+					}
+					if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+						t.Errorf("%s(%q, %t) error = %v, want %v", fn, tt.input, strict, err, tt.wantErr)
+					}
+				}
+			}
+		})
+	}
+
+	if _, _, err := ixdtf.Parse(tests[0].input, false); err != nil {
+		t.Errorf("Parse(%q) without the option unexpected error: %v", tests[0].input, err)
+	}
+}
+
+func TestWithOverPrecision(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		fraction     string
+		wantTruncate int
+		wantRound    int
+	}{
+		{"123456789", 123456789, 123456789},         // 9 digits: exact
+		{"1234567894", 123456789, 123456789},        // 10 digits, below half
+		{"1234567895", 123456789, 123456790},        // exactly half, odd: up to even
+		{"1234567885", 123456788, 123456788},        // exactly half, even: stays
+		{"123456788500", 123456788, 123456788},      // 12 digits, exactly half
+		{"123456788501", 123456788, 123456789},      // 12 digits, above half
+		{"123456789999", 123456789, 123456790},      // 12 digits, rounds up
+		{"9999999999", 999999999, int(time.Second)}, // carries into the seconds
+	}
+	for _, tt := range tests {
+		input := "2025-01-02T03:04:05." + tt.fraction + "Z[u-ca=gregory]"
+		truncated, _, err := ixdtf.Parse(input, true)
+		if err != nil {
+			t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+		}
+		if want := base.Add(time.Duration(tt.wantTruncate)); !truncated.Equal(want) {
+			t.Errorf("Parse(%q) = %v, want %v", input, truncated, want)
+		}
+		explicit, _, _ := ixdtf.Parse(input, true, ixdtf.WithOverPrecision(ixdtf.OverPrecisionTruncate))
+		if !explicit.Equal(truncated) {
+			t.Errorf("Parse(%q, OverPrecisionTruncate) = %v, want %v", input, explicit, truncated)
+		}
+		rounded, _, err := ixdtf.Parse(input, true, ixdtf.WithOverPrecision(ixdtf.OverPrecisionRound))
+		if err != nil {
+			t.Fatalf("Parse(%q, OverPrecisionRound) unexpected error: %v", input, err)
+		}
+		if want := base.Add(time.Duration(tt.wantRound)); !rounded.Equal(want) {
+			t.Errorf("Parse(%q, OverPrecisionRound) = %v, want %v", input, rounded, want)
+		}
+	}
+}
+
+func TestWithRejectMilitaryZones(t *testing.T) {
+	t.Parallel()
+
+	reject := ixdtf.WithRejectMilitaryZones()
+	for _, zone := range []string{"Z", "J", "A", "m"} {
+		input := "2025-01-01T00:00:00Z[" + zone + "][u-ca=gregory]"
+
+		_, ext, err := ixdtf.Parse(input, false)
+		if err != nil || ext.Location != nil {
+			t.Errorf("Parse(%q, false) = %v, %v, want the zone dropped", input, ext, err)
+		}
+
+		for _, strict := range []bool{false, true} {
+			_, _, err := ixdtf.Parse(input, strict, reject)
+			var tzErr *ixdtf.TimezoneError
+			if !errors.As(err, &tzErr) || !errors.Is(err, ixdtf.ErrInvalidTimezone) || tzErr.Name != zone {
+				t.Errorf("Parse(%q, %t) error = %v, want a TimezoneError for %q", input, strict, err, zone)
+			}
+			if err := ixdtf.Validate(input, strict, reject); !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+				t.Errorf("Validate(%q, %t) error = %v, want ErrInvalidTimezone", input, strict, err)
+			}
+		}
+	}
+
+	for _, input := range []string{"2025-01-01T00:00:00Z[UTC]", "2025-01-01T00:00:00Z[Mars/Olympus]"} {
+		if _, _, err := ixdtf.Parse(input, false, reject); err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", input, err)
+		}
+	}
+}
+
+func TestWithExpandedYears(t *testing.T) {
+	t.Parallel()
+
+	expanded := ixdtf.WithExpandedYears()
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"+012025-01-02T03:04:05Z", time.Date(12025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"-000044-03-15T12:00:00Z[u-ca=gregory]", time.Date(-44, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{"+002024-02-29T00:00:00.5+09:00", time.Date(2024, 2, 28, 15, 0, 0, 5e8, time.UTC)},
+		{"-000400-02-29T00:00:00Z", time.Date(-400, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"+012025-06-01T12:00:00-04:00[America/New_York]", time.Date(12025, 6, 1, 16, 0, 0, 0, time.UTC)},
+		{"2025-01-02T03:04:05Z", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			got, _, err := ixdtf.Parse(tt.input, strict, expanded)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("Parse(%q, %t) = %v, %v, want %v", tt.input, strict, got, err, tt.want)
+			}
+			if err := ixdtf.Validate(tt.input, strict, expanded); err != nil {
+				t.Errorf("Validate(%q, %t) unexpected error: %v", tt.input, strict, err)
+			}
+		}
+		if tt.input[0] == '+' || tt.input[0] == '-' {
+			if _, _, err := ixdtf.Parse(tt.input, false); err == nil {
+				t.Errorf("Parse(%q) without the option expected an error, got nil", tt.input)
+			}
+		}
+	}
+
+	for _, input := range []string{
+		"+012025-02-29T00:00:00Z", // 12025 is not a leap year
+		"-000000-01-01T00:00:00Z", // ISO 8601 writes year zero as +000000
+		"+12025-01-02T03:04:05Z",  // five digits
+		"012025-01-02T03:04:05Z",  // no sign
+		"+012025-06-01T12:00:00+09:00[America/New_York]",
+	} {
+		if _, _, err := ixdtf.Parse(input, true, expanded); err == nil {
+			t.Errorf("Parse(%q) expected an error, got nil", input)
+		}
+		if err := ixdtf.Validate(input, true, expanded); err == nil {
+			t.Errorf("Validate(%q) expected an error, got nil", input)
+		}
+	}
+}
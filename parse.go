@@ -1,7 +1,9 @@
 package ixdtf
 
 import (
+	"context"
 	"errors"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,52 +11,242 @@ import (
 )
 
 // Parse parses an IXDTF string and returns the time and extension information.
-func Parse(s string, strict bool) (time.Time, *IXDTFExtensions, error) {
+func Parse(s string, strict bool, opts ...ParseOption) (time.Time, *IXDTFExtensions, error) {
+	r, err := parse(s, newParseOptions(strict, opts))
+	return r.Time, r.Extensions, err
+}
+
+// ParseContext is like Parse but checks ctx before loading the time zone
+// named by the annotation, which may block on slow zoneinfo access. When ctx
+// is already done at that point, parsing stops with an error that matches
+// ctx.Err() under errors.Is. A load already in progress is not interrupted.
+func ParseContext(
+	ctx context.Context,
+	s string,
+	strict bool,
+	opts ...ParseOption,
+) (time.Time, *IXDTFExtensions, error) {
+	o := newParseOptions(strict, opts)
+	o.ctx = ctx
+	r, err := parse(s, o)
+	return r.Time, r.Extensions, err
+}
+
+// ParseLenient is Parse with strict set to false.
+func ParseLenient(s string, opts ...ParseOption) (time.Time, *IXDTFExtensions, error) {
+	return Parse(s, false, opts...)
+}
+
+// ParseStrict is Parse with strict set to true.
+func ParseStrict(s string, opts ...ParseOption) (time.Time, *IXDTFExtensions, error) {
+	return Parse(s, true, opts...)
+}
+
+// ParseResult is the outcome of ParseResultOf.
+type ParseResult struct {
+	Time       time.Time
+	Extensions *IXDTFExtensions
+
+	// Consistency is the time-zone consistency check (RFC 9557 Section 3.4)
+	// of the offset against the annotation, or nil when the string has no
+	// usable time-zone annotation.
+	Consistency *TimezoneConsistencyResult
+}
+
+// ParseResultOf parses s like Parse and returns everything it determined in
+// one value. The parse is non-strict unless WithStrict is given. On error the
+// zero ParseResult is returned.
+func ParseResultOf(s string, opts ...ParseOption) (ParseResult, error) {
+	return parse(s, newParseOptions(false, opts))
+}
+
+// InspectConsistency parses s non-strictly and returns its time-zone
+// consistency result (RFC 9557 Section 3.4) for reporting. Unlike Parse, it
+// never fails on a mismatch, even for a critical "[!zone]"; the result's
+// IsConsistent, OriginalOffset, and ExpectedOffset describe it, and Timezone
+// holds the declared zone name. A string without a time-zone annotation,
+// or with one that names an unknown zone, gives a consistent result whose
+// Location is nil. Other errors are those of Parse.
+func InspectConsistency(s string) (*TimezoneConsistencyResult, error) {
+	o := newParseOptions(false, nil)
+	o.recordDroppedZone = true
+	o.tolerateMismatch = true
+	r, err := parse(s, o)
+	if err != nil {
+		return nil, err
+	}
+	if r.Consistency == nil {
+		return &TimezoneConsistencyResult{IsConsistent: true, Timezone: r.Extensions.TimeZoneName()}, nil
+	}
+	return r.Consistency, nil
+}
+
+func parse(s string, o *parseOptions) (ParseResult, error) {
 	rfc3339End := findRFC3339End(s)
+	if o.trace != nil {
+		o.trace.addf("split at offset %d", rfc3339End)
+	}
 
-	t, err := parseRFC3339Portion(s[:rfc3339End])
+	rfc3339Portion := s[:rfc3339End]
+	var year int
+	var expanded bool
+	if o.expandedYears {
+		rfc3339Portion, year, expanded = normalizeExpandedYear(rfc3339Portion)
+	}
+	if o.lenientSeparators {
+		rfc3339Portion = normalizeSeparators(rfc3339Portion)
+	}
+	t, err := parseRFC3339Portion(rfc3339Portion)
 	if err != nil {
-		return time.Time{}, nil, newParseError(LayoutRFC3339, s, err)
+		return ParseResult{}, newParseError(LayoutRFC3339, s, err)
+	}
+	if expanded {
+		t = withYear(t, year)
+	}
+	if o.overPrecision == OverPrecisionRound && roundsUpToNanosecond(rfc3339Portion) {
+		t = t.Add(time.Nanosecond)
 	}
 
-	ext, result, err := parseExtensions(s, rfc3339End, t, strict)
+	ext, result, err := parseExtensions(s, rfc3339End, t, o)
 	if err != nil {
-		return time.Time{}, nil, err
+		return ParseResult{}, err
 	}
 
 	// Per RFC 9557: In non-strict mode with inconsistent timezone,
 	// preserve the original timestamp and only apply timezone if consistent
 	if result != nil && result.IsConsistent && result.Location != nil {
 		t = t.In(result.Location)
+		if o.trace != nil {
+			o.trace.addf("instant shifted to zone %s", result.Location)
+		}
 	}
 	// In non-strict mode with inconsistency, keep original timestamp as-is
 
-	return t, ext, nil
+	return ParseResult{Time: t, Extensions: ext, Consistency: result}, nil
+}
+
+// ParseMany parses each string in ss as Parse does, without stopping at the
+// first failure. The three returned slices have len(ss) elements and index i
+// of each refers to ss[i]: errs[i] is nil on success, and on failure times[i]
+// is the zero time and exts[i] is nil.
+func ParseMany(
+	ss []string,
+	strict bool,
+	opts ...ParseOption,
+) ([]time.Time, []*IXDTFExtensions, []error) {
+	times := make([]time.Time, len(ss))
+	exts := make([]*IXDTFExtensions, len(ss))
+	errs := make([]error, len(ss))
+	o := newParseOptions(strict, opts)
+	for i, s := range ss {
+		result, err := parse(s, o)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		times[i], exts[i] = result.Time, result.Extensions
+	}
+	return times, exts, errs
+}
+
+// ParseBytes is like Parse but takes the input as a byte slice, as read from
+// a network frame or file buffer. The input is copied into a string once:
+// the returned tags and any ParseError refer to that copy, so b may be
+// reused as soon as ParseBytes returns.
+func ParseBytes(b []byte, strict bool, opts ...ParseOption) (time.Time, *IXDTFExtensions, error) {
+	return Parse(string(b), strict, opts...)
+}
+
+// ValidateBytes is like Validate but takes the input as a byte slice. As with
+// ParseBytes, b is copied once and may be reused as soon as ValidateBytes
+// returns.
+func ValidateBytes(b []byte, strict bool, opts ...ParseOption) error {
+	return Validate(string(b), strict, opts...)
+}
+
+// MustParse is like Parse in non-strict mode but panics if s cannot be
+// parsed. It simplifies initialization of package-level variables and test
+// fixtures holding IXDTF literals.
+func MustParse(s string) (time.Time, *IXDTFExtensions) {
+	return mustParse(s, false)
+}
+
+// MustParseStrict is like MustParse but parses in strict mode.
+func MustParseStrict(s string) (time.Time, *IXDTFExtensions) {
+	return mustParse(s, true)
+}
+
+func mustParse(s string, strict bool) (time.Time, *IXDTFExtensions) {
+	t, ext, err := Parse(s, strict)
+	if err != nil {
+		panic("ixdtf: Parse(" + strconv.Quote(s) + "): " + err.Error())
+	}
+	return t, ext
+}
+
+// ParseToUTC parses s like Parse and returns the instant in UTC, so results
+// compare and sort without a separate UTC call. The returned extensions still
+// carry the annotated Location for display or reformatting.
+func ParseToUTC(s string, strict bool, opts ...ParseOption) (time.Time, *IXDTFExtensions, error) {
+	t, ext, err := Parse(s, strict, opts...)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return t.UTC(), ext, nil
 }
 
 // Validate validates an IXDTF string for format correctness without parsing the time component.
-func Validate(s string, strict bool) error {
+func Validate(s string, strict bool, opts ...ParseOption) error {
+	o := newParseOptions(strict, opts)
 	rfc3339End := findRFC3339End(s)
 	rfc3339Portion := s[:rfc3339End]
 
 	if rfc3339Portion == "" {
 		return newParseError(LayoutRFC3339, s, errors.New("empty datetime string"))
 	}
+	// The grammar checks below run on the normalized input so a lenient
+	// separator or an expanded year is not rejected there.
+	var year int
+	var expanded bool
+	if o.expandedYears {
+		rfc3339Portion, year, expanded = normalizeExpandedYear(rfc3339Portion)
+	}
+	if o.lenientSeparators {
+		rfc3339Portion = normalizeSeparators(rfc3339Portion)
+	}
+	grammarInput := s
+	if rfc3339Portion != s[:rfc3339End] {
+		grammarInput = rfc3339Portion + s[rfc3339End:]
+	}
+
+	// Bound the suffix up front so the ABNF pattern below never runs on an
+	// arbitrarily large input.
+	if err := checkSuffixBounds(s[rfc3339End:], o); err != nil {
+		return newParseError(LayoutRFC3339Extended, s, err)
+	}
 
 	// Parse the RFC3339 portion to validate format and get the timestamp
 	t, err := parseRFC3339Portion(rfc3339Portion)
 	if err != nil {
 		return newParseError(LayoutRFC3339, s, errors.New("invalid portion: "+err.Error()))
 	}
+	if expanded {
+		t = withYear(t, year)
+	}
 
-	if _, _, err = parseExtensions(s, rfc3339End, t, strict); err != nil {
+	if _, _, err = parseExtensions(s, rfc3339End, t, o); err != nil {
 		return err
 	}
 
-	// Check the complete string against the ABNF pattern as an additional
+	// Check the complete string against the ABNF grammar as an additional
 	// validation layer on top of the structural parse above.
-	if abnfErr := abnf.AbnfDateTimeExt.ValidateDateTimeExt(s); abnfErr != nil {
-		return newParseError(LayoutRFC3339Extended, s, abnfErr)
+	if scanDateTimeExt(grammarInput) >= 0 {
+		return newParseError(LayoutRFC3339Extended, s, ErrInvalidExtension)
+	}
+	if o.abnfCrossCheck {
+		if abnfErr := abnf.AbnfDateTimeExt.ValidateDateTimeExt(grammarInput); abnfErr != nil {
+			return newParseError(LayoutRFC3339Extended, s, abnfErr)
+		}
 	}
 
 	return nil
@@ -64,24 +256,30 @@ func Validate(s string, strict bool) error {
 // semantics shared by Parse and Validate: suffix grammar (Section 4.1),
 // extension validation (Section 3.3), and time-zone consistency
 // (Section 3.4, escalated to strict for a critical zone). The returned
-// consistency result is nil when no time-zone annotation applies.
+// consistency result is nil when no time-zone annotation applies. The tag
+// maps of the returned extensions are nil until a tag is stored.
 func parseExtensions(
 	s string,
 	rfc3339End int,
 	t time.Time,
-	strict bool,
+	opts *parseOptions,
 ) (*IXDTFExtensions, *TimezoneConsistencyResult, error) {
 	var ext *IXDTFExtensions
 	if rfc3339End < len(s) {
 		var err error
-		if ext, err = parseSuffix(s[rfc3339End:], strict); err != nil {
+		if ext, err = parseSuffix(s[rfc3339End:], opts); err != nil {
 			return nil, nil, newParseError(LayoutRFC3339Extended, s, err)
 		}
 	} else {
-		ext = NewIXDTFExtensions(nil)
+		ext = &IXDTFExtensions{}
 	}
+	_, offset := t.Zone()
+	ext.Offset = &offset
+	ext.UnknownOffset = strings.HasSuffix(s[:rfc3339End], "-00:00") ||
+		strings.HasSuffix(s[:rfc3339End], "-0000")
+	ext.ZuluOffset = strings.HasSuffix(s[:rfc3339End], "Z") || strings.HasSuffix(s[:rfc3339End], "z")
 
-	if err := validateExtensionsStrict(ext, strict); err != nil {
+	if err := validateExtensionsStrict(ext, opts.strict, opts.loader); err != nil {
 		return nil, nil, newParseError(LayoutRFC3339Extended, s, err)
 	}
 
@@ -91,14 +289,92 @@ func parseExtensions(
 
 	offsetUnknown := hasUnknownLocalOffset(s[:rfc3339End])
 	// A critical time zone must be acted upon, so an inconsistency is an
-	// error even in non-strict mode (RFC 9557 Section 3.4).
-	result, err := checkTimezoneConsistency(t, ext.Location, strict || ext.CriticalLocation, offsetUnknown)
+	// error even in non-strict mode (RFC 9557 Section 3.4). The caller can
+	// also ask for that without strictness elsewhere.
+	strict := (opts.strict || ext.CriticalLocation || opts.offsetConsistency) && !opts.tolerateMismatch
+	result, err := checkTimezoneConsistency(t, ext.Location, strict, offsetUnknown, opts.loader)
+	if opts.trace != nil {
+		traceConsistency(opts.trace, result, err, strict, offsetUnknown)
+	}
 	if err != nil {
-		return nil, nil, newParseError(LayoutRFC3339NanoExtended, s, err)
+		return nil, nil, newParseError(LayoutRFC3339Extended, s, err)
 	}
 	return ext, result, nil
 }
 
+// traceConsistency records the outcome of checkTimezoneConsistency.
+func traceConsistency(trace *ParseTrace, result *TimezoneConsistencyResult, err error, strict, offsetUnknown bool) {
+	switch {
+	case err != nil:
+		trace.addf("consistency check failed (strict=%t): %v", strict, err)
+	case offsetUnknown:
+		trace.addf("offset unknown (Z or -00:00), zone rules applied")
+	case result.IsConsistent:
+		trace.addf("offset consistent (%d seconds)", result.OriginalOffset)
+	default:
+		trace.addf(
+			"offset inconsistent (original %d, expected %d seconds), original offset kept",
+			result.OriginalOffset,
+			result.ExpectedOffset,
+		)
+	}
+}
+
+// Split splits an IXDTF string into its RFC 3339 date-time portion and its
+// bracketed suffix (RFC 9557 Section 4.1), without validating either part.
+// The split happens at the first '['; a string without one returns an empty
+// suffix. Split only slices, so it is cheap enough to use as a pre-filter,
+// and a malformed input still returns whatever the split produces.
+func Split(s string) (string, string) {
+	end := findRFC3339End(s)
+	return s[:end], s[end:]
+}
+
+// RFC3339Of returns the RFC 3339 date-time portion of s as split by Split.
+// The result is not validated.
+func RFC3339Of(s string) string {
+	return s[:findRFC3339End(s)]
+}
+
+// SuffixOf returns the bracketed suffix of s as split by Split, or an empty
+// string when s has no suffix. The result is not validated.
+func SuffixOf(s string) string {
+	return s[findRFC3339End(s):]
+}
+
+// HasSuffix reports whether s carries a bracketed IXDTF suffix, i.e. whether
+// SuffixOf(s) is non-empty. Like Split it only looks for the first '[' and
+// does not validate anything, so it is a cheap way to route plain RFC 3339
+// input around the extension machinery.
+func HasSuffix(s string) bool {
+	return findRFC3339End(s) < len(s)
+}
+
+// ExtractCalendar returns the value of the first "u-ca" suffix tag in s,
+// critical or not, without parsing or validating s. The boolean is false
+// when s has no such tag or the tag is not closed.
+func ExtractCalendar(s string) (string, bool) {
+	suffix := SuffixOf(s)
+	for {
+		i := strings.IndexByte(suffix, '[')
+		if i < 0 {
+			return "", false
+		}
+		suffix = suffix[i+1:]
+		body := strings.TrimPrefix(suffix, "!")
+		if value, ok := strings.CutPrefix(body, ExtensionUnicodeCalendar+"="); ok {
+			end := strings.IndexByte(value, ']')
+			if end < 0 {
+				return "", false
+			}
+			return value[:end], true
+		}
+	}
+}
+
+// findRFC3339End returns the byte index of the first '[' in s, where the
+// suffix starts, or len(s) when there is none. '[' is ASCII, so a byte scan
+// cannot match inside a multibyte rune.
 func findRFC3339End(s string) int {
 	if i := strings.IndexByte(s, '['); i >= 0 {
 		return i
@@ -108,8 +384,132 @@ func findRFC3339End(s string) int {
 
 // parseRFC3339Portion parses the RFC 3339 date-time part. The RFC 3339 layout
 // also accepts fractional seconds, so no separate nanosecond layout is needed.
+//
+// time.Parse would place an offset that matches the system zone in
+// time.Local, which Format then annotates with the system zone name; parsing
+// relative to UTC instead yields UTC for a zero offset and an unnamed fixed
+// zone otherwise, independent of the machine's zone.
 func parseRFC3339Portion(rfc3339Portion string) (time.Time, error) {
-	return time.Parse(time.RFC3339, rfc3339Portion)
+	return time.ParseInLocation(time.RFC3339, rfc3339Portion, time.UTC)
+}
+
+// normalizeSeparators rewrites a space or lower-case "t" date/time separator
+// to "T", a trailing lower-case "z" to "Z", and a basic "+hhmm" offset to
+// "+hh:mm", for WithLenientSeparators. The input is returned unchanged,
+// without allocating, when none is present.
+func normalizeSeparators(rfc3339Portion string) string {
+	const separatorIndex = len("2006-01-02")
+	fixSeparator := len(rfc3339Portion) > separatorIndex &&
+		(rfc3339Portion[separatorIndex] == ' ' || rfc3339Portion[separatorIndex] == 't')
+	fixZulu := strings.HasSuffix(rfc3339Portion, "z")
+	fixOffset := hasBasicOffset(rfc3339Portion)
+	if !fixSeparator && !fixZulu && !fixOffset {
+		return rfc3339Portion
+	}
+	b := []byte(rfc3339Portion)
+	if fixSeparator {
+		b[separatorIndex] = 'T'
+	}
+	if fixZulu {
+		b[len(b)-1] = 'Z'
+	}
+	if fixOffset {
+		minutes := string(b[len(b)-2:])
+		b = append(append(b[:len(b)-2], ':'), minutes...)
+	}
+	return string(b)
+}
+
+// hasBasicOffset reports whether rfc3339Portion ends in a numeric offset
+// without the colon, such as "+0900".
+func hasBasicOffset(rfc3339Portion string) bool {
+	const basicOffsetLen = len("+0900")
+	n := len(rfc3339Portion)
+	if n <= basicOffsetLen {
+		return false
+	}
+	if sign := rfc3339Portion[n-basicOffsetLen]; sign != '+' && sign != '-' {
+		return false
+	}
+	for i := n - basicOffsetLen + 1; i < n; i++ {
+		if !isDigit(rfc3339Portion[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeExpandedYear replaces an ISO 8601 expanded year, a sign and six
+// digits such as "+012025" or "-000044", at the start of rfc3339Portion with
+// a four-digit stand-in that time.Parse accepts, for WithExpandedYears. The
+// stand-in has the same leap-year status, so February 29 is accepted exactly
+// when the real year has it; withYear restores the year afterwards. The
+// input is returned unchanged, with ok false, when it has no expanded year.
+// "-000000" is not an expanded year, as ISO 8601 requires "+000000".
+func normalizeExpandedYear(rfc3339Portion string) (string, int, bool) {
+	const yearLen = len("+012025")
+	if len(rfc3339Portion) <= yearLen || rfc3339Portion[yearLen] != '-' {
+		return rfc3339Portion, 0, false
+	}
+	sign := rfc3339Portion[0]
+	if sign != '+' && sign != '-' {
+		return rfc3339Portion, 0, false
+	}
+	year := 0
+	for i := 1; i < yearLen; i++ {
+		c := rfc3339Portion[i]
+		if !isDigit(c) {
+			return rfc3339Portion, 0, false
+		}
+		year = year*10 + int(c-'0')
+	}
+	if sign == '-' {
+		if year == 0 {
+			return rfc3339Portion, 0, false
+		}
+		year = -year
+	}
+	standIn := "2001"
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		standIn = "2000"
+	}
+	return standIn + rfc3339Portion[yearLen:], year, true
+}
+
+// withYear returns t with its year replaced, keeping the wall clock and the
+// location.
+func withYear(t time.Time, year int) time.Time {
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// roundsUpToNanosecond reports whether the fractional seconds of
+// rfc3339Portion, which time.Parse truncates to nine digits, round up to the
+// next nanosecond under round-half-to-even.
+func roundsUpToNanosecond(rfc3339Portion string) bool {
+	const nanoDigits = 9
+	dot := strings.IndexByte(rfc3339Portion, '.')
+	if dot < 0 {
+		return false
+	}
+	end := dot + 1
+	for end < len(rfc3339Portion) && rfc3339Portion[end] >= '0' && rfc3339Portion[end] <= '9' {
+		end++
+	}
+	digits := rfc3339Portion[dot+1 : end]
+	if len(digits) <= nanoDigits {
+		return false
+	}
+	rest := digits[nanoDigits:]
+	switch {
+	case rest[0] > '5':
+		return true
+	case rest[0] < '5':
+		return false
+	case strings.TrimRight(rest[1:], "0") != "":
+		return true // above the half
+	default:
+		return (digits[nanoDigits-1]-'0')%2 == 1 // exactly half: round to even
+	}
 }
 
 // hasUnknownLocalOffset reports whether the RFC 3339 portion uses the
@@ -132,5 +532,5 @@ func hasUnknownLocalOffset(rfc3339Portion string) bool {
 			return true
 		}
 	}
-	return strings.HasSuffix(rfc3339Portion, "-00:00")
+	return strings.HasSuffix(rfc3339Portion, "-00:00") || strings.HasSuffix(rfc3339Portion, "-0000")
 }
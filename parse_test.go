@@ -1,11 +1,14 @@
 package ixdtf_test
 
 import (
+	"context"
+	"errors"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/8beeeaaat/ixdtf"
+	"github.com/8beeeaaat/ixdtf/abnf"
 )
 
 func TestParse(t *testing.T) {
@@ -211,6 +214,23 @@ func TestParse(t *testing.T) {
 			strict:  true,
 			wantErr: "timezone offset does not match",
 		},
+		{
+			// Etc/GMT+5 is UTC-05:00 (POSIX-inverted sign), so the same digits
+			// with a "+" offset are a real mismatch rather than unverifiable.
+			name:    "Etc/GMT+5 with inverted-sign offset errors in strict mode",
+			input:   "2025-01-01T00:00:00+05:00[Etc/GMT+5]",
+			strict:  true,
+			wantErr: "timezone offset does not match",
+		},
+		{
+			name:     "Etc/GMT+5 with matching offset in strict mode",
+			input:    "2025-01-01T00:00:00-05:00[Etc/GMT+5]",
+			strict:   true,
+			wantTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.FixedZone("Etc/GMT+5", -5*3600)),
+			wantExt: ixdtf.NewIXDTFExtensions(
+				&ixdtf.NewIXDTFExtensionsArgs{Location: time.FixedZone("Etc/GMT+5", -5*3600)},
+			),
+		},
 		{
 			name:     "timezone with Etc/GMT pattern in strict mode",
 			input:    "2025-01-01T00:00:00+05:00[Etc/GMT-5]",
@@ -581,6 +601,46 @@ func TestParseCriticalTimezone(t *testing.T) {
 		_, _, err := ixdtf.Parse(input, false)
 		checkParseError(t, err, input, false, "timezone offset does not match")
 	})
+
+	// RFC 9557 Section 4.1 allows the critical flag on every time-zone form,
+	// so Parse, Validate, and the abnf grammar all accept critical offsets
+	// and names alike, and Format keeps the flag.
+	t.Run("critical offsets and names are valid in every layer", func(t *testing.T) {
+		t.Parallel()
+		for _, input := range []string{
+			"2025-01-02T03:04:05+09:00[!+09:00]",
+			"2025-01-02T03:04:05-05:30[!-05:30]",
+			"2025-01-02T03:04:05+09:00[!Asia/Tokyo]",
+		} {
+			if err := abnf.ValidateFull(input); err != nil {
+				t.Errorf("abnf.ValidateFull(%q) unexpected error: %v", input, err)
+			}
+			for _, strict := range []bool{false, true} {
+				if err := ixdtf.Validate(input, strict); err != nil {
+					t.Errorf("Validate(%q, %v) unexpected error: %v", input, strict, err)
+				}
+				got, ext, err := ixdtf.Parse(input, strict)
+				if err != nil {
+					t.Fatalf("Parse(%q, %v) unexpected error: %v", input, strict, err)
+				}
+				wantZone := ixdtf.SuffixOf(input)[len("[!") : len(ixdtf.SuffixOf(input))-1]
+				if !ext.CriticalLocation || ext.TimeZoneName() != wantZone {
+					t.Errorf("Parse(%q, %v) = zone %q critical %t, want %q critical",
+						input, strict, ext.TimeZoneName(), ext.CriticalLocation, wantZone)
+				}
+				if formatted, _ := ixdtf.Format(got, ext); formatted != input {
+					t.Errorf("Format(Parse(%q, %v)) = %q", input, strict, formatted)
+				}
+			}
+		}
+	})
+
+	t.Run("inconsistent critical offset errors in non-strict mode", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-02T03:04:05+09:00[!-05:30]"
+		_, _, err := ixdtf.Parse(input, false)
+		checkParseError(t, err, input, false, "timezone offset does not match")
+	})
 }
 
 // TestParseNumericZeroOffsetStillInconsistent verifies the contrast with Z: a
@@ -606,3 +666,494 @@ func TestParseNumericZeroOffsetStillInconsistent(t *testing.T) {
 		t.Errorf("Parse(%q, false) offset = %d, want 0 (original preserved)", input, off)
 	}
 }
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        string
+		wantDatetime string
+		wantSuffix   string
+	}{
+		{
+			name:         "plain RFC 3339",
+			input:        "2025-01-02T03:04:05Z",
+			wantDatetime: "2025-01-02T03:04:05Z",
+			wantSuffix:   "",
+		},
+		{
+			name:         "timezone and tags",
+			input:        "2025-02-03T04:05:06+09:00[Asia/Tokyo][!u-ca=gregory]",
+			wantDatetime: "2025-02-03T04:05:06+09:00",
+			wantSuffix:   "[Asia/Tokyo][!u-ca=gregory]",
+		},
+		{
+			name:         "empty string",
+			input:        "",
+			wantDatetime: "",
+			wantSuffix:   "",
+		},
+		{
+			// Split does not validate, so malformed input is sliced as-is.
+			name:         "malformed input is split without validation",
+			input:        "not-a-date[unclosed",
+			wantDatetime: "not-a-date",
+			wantSuffix:   "[unclosed",
+		},
+		{
+			name:         "suffix only",
+			input:        "[Asia/Tokyo]",
+			wantDatetime: "",
+			wantSuffix:   "[Asia/Tokyo]",
+		},
+		{
+			// The split point is a byte index: multibyte runes before the
+			// '[' shift it by their encoded length, not by one.
+			name:         "multibyte prefix",
+			input:        "２０２５年—東京[Asia/Tokyo]",
+			wantDatetime: "２０２５年—東京",
+			wantSuffix:   "[Asia/Tokyo]",
+		},
+		{
+			name:         "multibyte suffix",
+			input:        "2025-01-02T03:04:05Z[t-名=値]",
+			wantDatetime: "2025-01-02T03:04:05Z",
+			wantSuffix:   "[t-名=値]",
+		},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gotDatetime, gotSuffix := ixdtf.Split(tc.input)
+			if gotDatetime != tc.wantDatetime || gotSuffix != tc.wantSuffix {
+				t.Fatalf(
+					"Split(%q) = (%q, %q), want (%q, %q)",
+					tc.input, gotDatetime, gotSuffix, tc.wantDatetime, tc.wantSuffix,
+				)
+			}
+			if got := ixdtf.RFC3339Of(tc.input); got != tc.wantDatetime {
+				t.Errorf("RFC3339Of(%q) = %q, want %q", tc.input, got, tc.wantDatetime)
+			}
+			if got := ixdtf.SuffixOf(tc.input); got != tc.wantSuffix {
+				t.Errorf("SuffixOf(%q) = %q, want %q", tc.input, got, tc.wantSuffix)
+			}
+		})
+	}
+}
+
+// TestParseZoneAliases verifies that IANA backward links (e.g. Asia/Calcutta
+// for Asia/Kolkata) are checked against the rules of the zone they link to,
+// so strict mode accepts a matching offset, while the annotation keeps the
+// name as written for a lossless round trip.
+func TestParseZoneAliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:  "Asia/Calcutta with matching offset",
+			input: "2025-01-01T00:00:00+05:30[Asia/Calcutta]",
+		},
+		{
+			name:  "Africa/Asmera with matching offset",
+			input: "2025-01-01T00:00:00+03:00[Africa/Asmera]",
+		},
+		{
+			name:    "Asia/Calcutta with mismatching offset",
+			input:   "2025-01-01T00:00:00+05:00[Asia/Calcutta]",
+			wantErr: "timezone offset does not match",
+		},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, ext, err := ixdtf.Parse(tc.input, true)
+			if tc.wantErr != "" {
+				checkParseError(t, err, tc.input, true, tc.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, true) unexpected error: %v", tc.input, err)
+			}
+			formatted, err := ixdtf.Format(got, ext)
+			if err != nil {
+				t.Fatalf("Format unexpected error: %v", err)
+			}
+			if formatted != tc.input {
+				t.Errorf("round trip failed: got %q, want %q", formatted, tc.input)
+			}
+		})
+	}
+}
+
+// TestParseFractionalSeconds verifies that the single RFC 3339 layout used for
+// the date-time portion accepts every fraction length from none to
+// nanoseconds, so no second parse attempt is needed.
+func TestParseFractionalSeconds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		wantNano int
+	}{
+		{"2025-01-02T03:04:05Z", 0},
+		{"2025-01-02T03:04:05.1Z", 100000000},
+		{"2025-01-02T03:04:05.12Z", 120000000},
+		{"2025-01-02T03:04:05.123Z", 123000000},
+		{"2025-01-02T03:04:05.123456Z", 123456000},
+		{"2025-01-02T03:04:05.123456789Z", 123456789},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			got, _, err := ixdtf.Parse(tc.input, true)
+			if err != nil {
+				t.Fatalf("Parse(%q, true) unexpected error: %v", tc.input, err)
+			}
+			if got.Nanosecond() != tc.wantNano {
+				t.Errorf("Parse(%q, true) nanosecond = %d, want %d", tc.input, got.Nanosecond(), tc.wantNano)
+			}
+		})
+	}
+}
+
+func TestParseInvalidRFC3339(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"2025-01-02",
+		"2025-01-02T03:04Z",
+		"2025-01-02T03:04:05",
+		"2025-01-02T03:04:05.Z",
+		"2025-01-02T03:04:05+0900",
+		"2025-13-02T03:04:05Z",
+		"2025-01-02T24:04:05Z",
+		"2025-01-02 03:04:05Z",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			for _, strict := range []bool{false, true} {
+				_, _, err := ixdtf.Parse(input, strict)
+				checkParseError(t, err, input, strict, "IXDTFE parsing time")
+			}
+		})
+	}
+}
+
+func TestParseToUTC(t *testing.T) {
+	t.Parallel()
+
+	const input = "2025-02-03T04:05:06+09:00[Asia/Tokyo][u-ca=gregory]"
+	want, _, err := ixdtf.Parse(input, true)
+	if err != nil {
+		t.Fatalf("Parse(%q) unexpected error: %v", input, err)
+	}
+
+	got, ext, err := ixdtf.ParseToUTC(input, true)
+	if err != nil {
+		t.Fatalf("ParseToUTC(%q) unexpected error: %v", input, err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("ParseToUTC(%q) instant = %v, want %v", input, got, want)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("ParseToUTC(%q) location = %v, want UTC", input, got.Location())
+	}
+	if ext.Location == nil || ext.Location.String() != "Asia/Tokyo" {
+		t.Errorf("ParseToUTC(%q) ext.Location = %v, want Asia/Tokyo", input, ext.Location)
+	}
+
+	if _, _, err = ixdtf.ParseToUTC("2025-06-01T12:00:00+09:00[America/New_York]", true); err == nil {
+		t.Error("ParseToUTC() expected error for inconsistent offset in strict mode")
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"2025-06-01T12:00:00+09:00[America/New_York]", 9 * 3600},
+		{"2025-06-01T12:00:00-04:00[America/New_York]", -4 * 3600},
+		{"2025-06-01T12:00:00Z[America/New_York]", 0},
+		{"2025-06-01T12:00:00-00:00", 0},
+		{"2025-06-01T12:00:00+05:30", 5*3600 + 30*60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			_, ext, err := ixdtf.Parse(tt.input, false)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if ext.Offset == nil || *ext.Offset != tt.want {
+				t.Errorf("Parse(%q) ext.Offset = %v, want %d", tt.input, ext.Offset, tt.want)
+			}
+		})
+	}
+
+	if ext := ixdtf.NewIXDTFExtensions(nil); ext.Offset != nil {
+		t.Errorf("NewIXDTFExtensions(nil).Offset = %v, want nil", *ext.Offset)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	t.Parallel()
+
+	got, ext := ixdtf.MustParse("2025-02-03T04:05:06+09:00[Asia/Tokyo]")
+	if got.Unix() != 1738523106 || ext.Location == nil || ext.Location.String() != "Asia/Tokyo" {
+		t.Errorf("MustParse() = (%v, %+v)", got, ext)
+	}
+
+	mustPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s did not panic", name)
+			}
+		}()
+		f()
+	}
+	mustPanic("MustParse(invalid)", func() { ixdtf.MustParse("not a time") })
+	// Inconsistent offsets only fail in strict mode.
+	const inconsistent = "2025-06-01T12:00:00+09:00[America/New_York]"
+	ixdtf.MustParse(inconsistent)
+	mustPanic("MustParseStrict(inconsistent)", func() { ixdtf.MustParseStrict(inconsistent) })
+}
+
+func TestParseContext(t *testing.T) {
+	t.Parallel()
+
+	const input = "2025-02-03T04:05:06+09:00[Asia/Tokyo][u-ca=gregory]"
+	got, ext, err := ixdtf.ParseContext(context.Background(), input, true)
+	if err != nil {
+		t.Fatalf("ParseContext(%q) unexpected error: %v", input, err)
+	}
+	if got.Unix() != 1738523106 || ext.TimeZoneName() != "Asia/Tokyo" {
+		t.Errorf("ParseContext(%q) = (%v, %#v)", input, got, ext)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := ixdtf.ParseContext(ctx, input, false); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext(canceled) error = %v, want %v", err, context.Canceled)
+	}
+	// Without a time-zone annotation nothing is loaded, so nothing is checked.
+	if _, _, err := ixdtf.ParseContext(ctx, "2025-02-03T04:05:06Z[u-ca=gregory]", false); err != nil {
+		t.Errorf("ParseContext(canceled, no zone) unexpected error: %v", err)
+	}
+}
+
+func TestParseMany(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"2025-02-03T04:05:06+09:00[Asia/Tokyo]",
+		"not a date",
+		"2025-02-03T04:05:06Z[u-ca=gregory]",
+		"2025-02-03T04:05:06+09:00[Invalid/Zone]",
+	}
+	times, exts, errs := ixdtf.ParseMany(inputs, true)
+	if len(times) != len(inputs) || len(exts) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatalf("ParseMany() lengths = %d, %d, %d, want %d", len(times), len(exts), len(errs), len(inputs))
+	}
+	for i, input := range inputs {
+		wantTime, wantExt, wantErr := ixdtf.Parse(input, true)
+		if (errs[i] == nil) != (wantErr == nil) {
+			t.Errorf("errs[%d] = %v, want %v", i, errs[i], wantErr)
+			continue
+		}
+		if wantErr != nil {
+			if exts[i] != nil || !times[i].IsZero() {
+				t.Errorf("item %d failed but has time %v and extensions %#v", i, times[i], exts[i])
+			}
+			continue
+		}
+		if !times[i].Equal(wantTime) || exts[i].TimeZoneName() != wantExt.TimeZoneName() {
+			t.Errorf("item %d = %v %#v, want %v %#v", i, times[i], exts[i], wantTime, wantExt)
+		}
+	}
+	if errs[0] != nil || errs[1] == nil || errs[2] != nil || errs[3] == nil {
+		t.Errorf("errs = %v, want failures at indexes 1 and 3 only", errs)
+	}
+
+	// Options apply to every item.
+	if _, _, errs := ixdtf.ParseMany(inputs[3:], false); errs[0] != nil {
+		t.Errorf("non-strict ParseMany() error = %v, want nil", errs[0])
+	}
+	if times, _, _ := ixdtf.ParseMany(nil, true); len(times) != 0 {
+		t.Errorf("ParseMany(nil) returned %d times, want 0", len(times))
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	t.Parallel()
+
+	buf := []byte("2025-02-03T04:05:06+09:00[Asia/Tokyo][u-ca=gregory]")
+	got, ext, err := ixdtf.ParseBytes(buf, true)
+	if err != nil {
+		t.Fatalf("ParseBytes() unexpected error: %v", err)
+	}
+	want, _, _ := ixdtf.Parse(string(buf), true)
+	if !got.Equal(want) {
+		t.Errorf("ParseBytes() = %v, want %v", got, want)
+	}
+
+	// The buffer may be reused once ParseBytes returns.
+	for i := range buf {
+		buf[i] = 'x'
+	}
+	if ext.Tags["u-ca"] != "gregory" {
+		t.Errorf("ParseBytes() tags changed after buffer reuse: %v", ext.Tags)
+	}
+
+	if err = ixdtf.ValidateBytes([]byte("2025-01-01T00:00:00Z[u-ca=gregory]"), true); err != nil {
+		t.Errorf("ValidateBytes() unexpected error: %v", err)
+	}
+	if err = ixdtf.ValidateBytes([]byte("2025-01-01T00:00:00Z[INVALID=x]"), false); err == nil {
+		t.Error("ValidateBytes() expected error for upper-case key")
+	}
+}
+
+func TestHasSuffixAndExtractCalendar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input        string
+		hasSuffix    bool
+		calendar     string
+		calendarSeen bool
+	}{
+		{"2025-01-01T00:00:00Z", false, "", false},
+		{"2025-01-01T00:00:00Z[Asia/Tokyo]", true, "", false},
+		{"2025-01-01T00:00:00Z[Asia/Tokyo][u-ca=japanese]", true, "japanese", true},
+		{"2025-01-01T00:00:00Z[!u-ca=hebrew][u-ca=gregory]", true, "hebrew", true},
+		{"2025-01-01T00:00:00Z[x-u-ca=roc][u-ca=roc]", true, "roc", true},
+		{"2025-01-01T00:00:00Z[u-ca=gregory", true, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := ixdtf.HasSuffix(tt.input); got != tt.hasSuffix {
+				t.Errorf("HasSuffix(%q) = %t, want %t", tt.input, got, tt.hasSuffix)
+			}
+			calendar, ok := ixdtf.ExtractCalendar(tt.input)
+			if calendar != tt.calendar || ok != tt.calendarSeen {
+				t.Errorf("ExtractCalendar(%q) = (%q, %t), want (%q, %t)",
+					tt.input, calendar, ok, tt.calendar, tt.calendarSeen)
+			}
+		})
+	}
+}
+
+func TestParseResultOf(t *testing.T) {
+	t.Parallel()
+
+	const inconsistent = "2025-06-01T12:00:00+09:00[America/New_York][u-ca=gregory]"
+	r, err := ixdtf.ParseResultOf(inconsistent)
+	if err != nil {
+		t.Fatalf("ParseResultOf(%q) unexpected error: %v", inconsistent, err)
+	}
+	wantTime, wantExt, _ := ixdtf.Parse(inconsistent, false)
+	if !r.Time.Equal(wantTime) || !extensionsEqual(r.Extensions, wantExt) {
+		t.Errorf("ParseResultOf(%q) = %+v, want Parse result (%v, %+v)", inconsistent, r, wantTime, wantExt)
+	}
+	if r.Consistency == nil || r.Consistency.IsConsistent || r.Consistency.OriginalOffset != 9*3600 {
+		t.Errorf("ParseResultOf(%q) Consistency = %+v, want inconsistent +09:00", inconsistent, r.Consistency)
+	}
+
+	if _, err = ixdtf.ParseResultOf(inconsistent, ixdtf.WithStrict()); err == nil {
+		t.Errorf("ParseResultOf(%q, WithStrict()) expected error", inconsistent)
+	}
+
+	r, err = ixdtf.ParseResultOf("2025-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("ParseResultOf() unexpected error: %v", err)
+	}
+	if r.Consistency != nil {
+		t.Errorf("ParseResultOf() without zone Consistency = %+v, want nil", r.Consistency)
+	}
+}
+
+func TestInspectConsistency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input          string
+		wantConsistent bool
+		wantTimezone   string
+		wantOriginal   int
+		wantExpected   int
+	}{
+		{"2025-06-01T12:00:00-04:00[America/New_York]", true, "America/New_York", -4 * 3600, -4 * 3600},
+		{"2025-06-01T12:00:00+09:00[America/New_York]", false, "America/New_York", 9 * 3600, -4 * 3600},
+		{"2025-06-01T12:00:00+09:00[!America/New_York]", false, "America/New_York", 9 * 3600, -4 * 3600},
+		{"2025-06-01T12:00:00+09:00[+05:00]", false, "+05:00", 9 * 3600, 5 * 3600},
+		{"2025-06-01T12:00:00+09:00[Mars/Olympus]", true, "Mars/Olympus", 0, 0},
+		{"2025-06-01T12:00:00+09:00", true, "", 0, 0},
+	}
+	for _, tt := range tests {
+		res, err := ixdtf.InspectConsistency(tt.input)
+		if err != nil {
+			t.Fatalf("InspectConsistency(%q) unexpected error: %v", tt.input, err)
+		}
+		if res.IsConsistent != tt.wantConsistent || res.Timezone != tt.wantTimezone ||
+			res.OriginalOffset != tt.wantOriginal || res.ExpectedOffset != tt.wantExpected {
+			t.Errorf("InspectConsistency(%q) = %+v, want consistent %t, timezone %q, offsets %d/%d",
+				tt.input, res, tt.wantConsistent, tt.wantTimezone, tt.wantOriginal, tt.wantExpected)
+		}
+	}
+
+	if _, err := ixdtf.InspectConsistency("2025-06-01T12:00:00+09:00[bad"); !errors.Is(err, ixdtf.ErrInvalidSuffix) {
+		t.Errorf("InspectConsistency(malformed) error = %v, want ErrInvalidSuffix", err)
+	}
+}
+
+// FuzzParse checks that Parse and Validate never panic on untrusted input and
+// that Validate never accepts what Parse rejects.
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"2025-01-02T03:04:05Z",
+		"2025-02-03T04:05:06.123+09:00[Asia/Tokyo][!u-ca=gregory]",
+		"2025-06-01T12:00:00+09:00[America/New_York]",
+		"2025-01-01T00:00:00Z[+09:00][a=b-c]",
+		"2025-01-01T00:00:00Z[",
+		"2025-01-01T00:00:00Z[]]",
+		"2025-01-01T00:00:00Z[!]",
+		"2025-01-01T00:00:00Z[=]",
+		"2025-01-01T00:00:00Z[!a=]",
+		"2025-01-01T00:00:00Z[+09]",
+		"2025-01-01T00:00:00+0",
+		"2025-01-01T00:00:00Z[u-ca=\x00]",
+		"2025-01-01T00:00:00Z[\xff\xfe]",
+		"",
+	} {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+
+	f.Fuzz(func(t *testing.T, s string, strict bool) {
+		_, _, parseErr := ixdtf.Parse(s, strict)
+		validateErr := ixdtf.Validate(s, strict)
+		if validateErr == nil && parseErr != nil {
+			t.Errorf("Validate(%q, %t) accepted input Parse rejected: %v", s, strict, parseErr)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package ixdtf
+
+import "sync"
+
+// ExtensionHandler processes the value of a recognized suffix key. It returns
+// an error when the value cannot be processed.
+type ExtensionHandler func(value string) error
+
+// ExtensionRegistry records the suffix keys a strict parse recognizes. RFC
+// 9557 Section 3.3 requires a recipient to reject a critical suffix key it
+// does not understand, so a strict parse fails with ErrCriticalExtension on
+// a critical key that is not registered, and with the handler's error when
+// the handler rejects the value. Elective keys are never looked up.
+//
+// An ExtensionRegistry is safe for concurrent use.
+type ExtensionRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]ExtensionHandler
+}
+
+// NewExtensionRegistry returns a registry with the keys this package
+// understands pre-registered: ExtensionUnicodeCalendar ("u-ca") and
+// ExtensionUnicodeNumberingSystem ("u-nu").
+func NewExtensionRegistry() *ExtensionRegistry {
+	r := &ExtensionRegistry{handlers: make(map[string]ExtensionHandler)}
+	r.Register(ExtensionUnicodeCalendar, func(value string) error {
+		return validateTagValue(ExtensionUnicodeCalendar, value)
+	})
+	r.Register(ExtensionUnicodeNumberingSystem, nil)
+	return r
+}
+
+// Register marks key as recognized. A nil handler accepts any value that
+// passes the suffix-value grammar. Registering a key again replaces its
+// handler.
+func (r *ExtensionRegistry) Register(key string, handler ExtensionHandler) {
+	if handler == nil {
+		handler = func(string) error { return nil }
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[key] = handler
+}
+
+// process runs the handler for a critical key, failing when none is
+// registered.
+func (r *ExtensionRegistry) process(key, value string) error {
+	r.mu.RLock()
+	handler, ok := r.handlers[key]
+	r.mu.RUnlock()
+	if !ok {
+		return ErrCriticalExtension
+	}
+	return handler(value)
+}
+
+// defaultExtensionRegistry is used by every parse that does not pass
+// WithExtensionRegistry.
+//
+//nolint:gochecknoglobals // Package-level registry backs RegisterExtension.
+var defaultExtensionRegistry = NewExtensionRegistry()
+
+// RegisterExtension marks key as recognized in the package-wide default
+// registry, which is consulted by every strict parse that does not pass
+// WithExtensionRegistry. It is typically called from an init function.
+func RegisterExtension(key string, handler ExtensionHandler) {
+	defaultExtensionRegistry.Register(key, handler)
+}
+
+// WithExtensionRegistry makes a parse consult r instead of the default
+// registry, so different callers can recognize different extensions. A nil
+// registry restores the default.
+func WithExtensionRegistry(r *ExtensionRegistry) ParseOption {
+	return func(o *parseOptions) {
+		if r == nil {
+			r = defaultExtensionRegistry
+		}
+		o.extensions = r
+	}
+}
@@ -0,0 +1,111 @@
+package ixdtf_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestExtensionRegistry(t *testing.T) {
+	t.Parallel()
+
+	errBadFormat := errors.New("bad t-format value")
+	custom := ixdtf.NewExtensionRegistry()
+	custom.Register("t-format", func(value string) error {
+		if value != "iso" {
+			return errBadFormat
+		}
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		input   string
+		strict  bool
+		opts    []ixdtf.ParseOption
+		wantErr error
+	}{
+		{
+			name:   "strict accepts pre-registered u-ca",
+			input:  "2025-03-04T05:06:07Z[!u-ca=gregory]",
+			strict: true,
+		},
+		{
+			name:   "strict accepts pre-registered u-nu",
+			input:  "2025-03-04T05:06:07Z[!u-nu=latn]",
+			strict: true,
+		},
+		{
+			name:    "strict rejects unregistered critical key",
+			input:   "2025-03-04T05:06:07Z[!totally-unknown=x]",
+			strict:  true,
+			wantErr: ixdtf.ErrCriticalExtension,
+		},
+		{
+			name:   "strict accepts unregistered elective key",
+			input:  "2025-03-04T05:06:07Z[totally-unknown=x]",
+			strict: true,
+		},
+		{
+			name:  "non-strict tolerates unregistered critical key",
+			input: "2025-03-04T05:06:07Z[!totally-unknown=x]",
+		},
+		{
+			name:   "per-parse registry recognizes custom key",
+			input:  "2025-03-04T05:06:07Z[!t-format=iso]",
+			strict: true,
+			opts:   []ixdtf.ParseOption{ixdtf.WithExtensionRegistry(custom)},
+		},
+		{
+			name:    "per-parse registry handler rejects value",
+			input:   "2025-03-04T05:06:07Z[!t-format=rfc]",
+			strict:  true,
+			opts:    []ixdtf.ParseOption{ixdtf.WithExtensionRegistry(custom)},
+			wantErr: errBadFormat,
+		},
+		{
+			name:    "custom key is not in the default registry",
+			input:   "2025-03-04T05:06:07Z[!t-format=iso]",
+			strict:  true,
+			wantErr: ixdtf.ErrCriticalExtension,
+		},
+		{
+			name:   "nil registry restores the default",
+			input:  "2025-03-04T05:06:07Z[!u-ca=gregory]",
+			strict: true,
+			opts:   []ixdtf.ParseOption{ixdtf.WithExtensionRegistry(nil)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := ixdtf.Parse(tt.input, tt.strict, tt.opts...)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRegisterExtension mutates the default registry with a key no other
+// test uses.
+func TestRegisterExtension(t *testing.T) {
+	t.Parallel()
+
+	input := "2025-03-04T05:06:07Z[!t-registered-globally=yes]"
+	if _, _, err := ixdtf.Parse(input, true); !errors.Is(err, ixdtf.ErrCriticalExtension) {
+		t.Fatalf("Parse() before RegisterExtension error = %v, want ErrCriticalExtension", err)
+	}
+	ixdtf.RegisterExtension("t-registered-globally", nil)
+	if _, _, err := ixdtf.Parse(input, true); err != nil {
+		t.Fatalf("Parse() after RegisterExtension unexpected error: %v", err)
+	}
+}
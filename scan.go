@@ -0,0 +1,256 @@
+package ixdtf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Explain describes where s first departs from the RFC 9557 date-time-ext
+// grammar (Section 4.1), for showing to people who edit timestamps by hand.
+// It returns s on one line and a caret under the offending character on the
+// next, followed by what was found there, e.g.
+//
+//	2025-01-01T00:00:00Z[invalid@key=value]
+//	                            ^ unexpected '@' at offset 28
+//
+// It returns "" when s matches the grammar. Explain only checks syntax;
+// Validate can still reject a well-formed string, for example for an unknown
+// time zone or an inconsistent offset.
+func Explain(s string) string {
+	i := scanDateTimeExt(s)
+	if i < 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(s)
+	b.WriteByte('\n')
+	b.WriteString(strings.Repeat(" ", utf8.RuneCountInString(s[:i])))
+	b.WriteString("^ ")
+	if i >= len(s) {
+		b.WriteString("unexpected end of input")
+		return b.String()
+	}
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	b.WriteString("unexpected ")
+	b.WriteString(strconv.QuoteRune(r))
+	b.WriteString(" at offset ")
+	b.WriteString(strconv.Itoa(i))
+	return b.String()
+}
+
+// scanDateTimeExt reports the index of the first byte of s that does not fit
+// the RFC 9557 date-time-ext grammar (Section 4.1) as matched by
+// abnf.AbnfDateTimeExt, or -1 when s matches. A truncated input reports
+// len(s). It accepts exactly the strings the pattern accepts, in one pass
+// and without allocating, so Validate uses it in place of the regexp.
+func scanDateTimeExt(s string) int {
+	i, ok := scanDateTime(s)
+	if !ok {
+		return i
+	}
+	for i < len(s) {
+		if i, ok = scanSuffixElement(s, i); !ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// scanDateTime scans the RFC 3339 date-time at the start of s. It returns the
+// index just past it, or the offending index and false.
+func scanDateTime(s string) (int, bool) {
+	sc := dateTimeScanner{s: s, ok: true}
+	sc.digits(4)
+	sc.literal('-')
+	sc.twoDigits(1, 12) // month
+	sc.literal('-')
+	sc.twoDigits(1, 31) // day
+	sc.literal('T')
+	sc.twoDigits(0, 23) // hour
+	sc.literal(':')
+	sc.digits(2)
+	sc.literal(':')
+	sc.digits(2)
+	sc.fraction()
+	sc.offset()
+	return sc.i, sc.ok
+}
+
+// dateTimeScanner walks a fixed sequence of date-time fields. Once a field
+// fails, ok stays false and i keeps the offending index.
+type dateTimeScanner struct {
+	s  string
+	i  int
+	ok bool
+}
+
+func (sc *dateTimeScanner) literal(c byte) {
+	if !sc.ok {
+		return
+	}
+	if sc.i < len(sc.s) && sc.s[sc.i] == c {
+		sc.i++
+		return
+	}
+	sc.ok = false
+}
+
+func (sc *dateTimeScanner) digits(n int) {
+	for range n {
+		if !sc.ok {
+			return
+		}
+		if sc.i < len(sc.s) && isDigit(sc.s[sc.i]) {
+			sc.i++
+			continue
+		}
+		sc.ok = false
+	}
+}
+
+// twoDigits scans a two-digit field whose value lies in [lo, hi]. An
+// out-of-range value reports the field's first byte.
+func (sc *dateTimeScanner) twoDigits(lo, hi int) {
+	start := sc.i
+	sc.digits(2)
+	if !sc.ok {
+		return
+	}
+	if v := int(sc.s[start]-'0')*10 + int(sc.s[start+1]-'0'); v < lo || v > hi {
+		sc.i = start
+		sc.ok = false
+	}
+}
+
+// fraction scans an optional "." followed by at least one digit.
+func (sc *dateTimeScanner) fraction() {
+	if !sc.ok || sc.i >= len(sc.s) || sc.s[sc.i] != '.' {
+		return
+	}
+	sc.i++
+	start := sc.i
+	for sc.i < len(sc.s) && isDigit(sc.s[sc.i]) {
+		sc.i++
+	}
+	sc.ok = sc.i > start
+}
+
+// offset scans "Z" or a numeric offset "+HH:MM" / "-HH:MM".
+func (sc *dateTimeScanner) offset() {
+	if !sc.ok {
+		return
+	}
+	if sc.i < len(sc.s) && sc.s[sc.i] == 'Z' {
+		sc.i++
+		return
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+		sc.digits(2)
+		sc.literal(':')
+		sc.digits(2)
+		return
+	}
+	sc.ok = false
+}
+
+// scanSuffixElement scans one bracketed suffix element starting at s[i]:
+// the empty "[]", a time-zone annotation "[!?name-or-offset]", or a suffix
+// tag "[!?key=value]".
+func scanSuffixElement(s string, i int) (int, bool) {
+	if s[i] != '[' {
+		return i, false
+	}
+	i++
+	if i < len(s) && s[i] == ']' {
+		return i + 1, true
+	}
+	if i < len(s) && s[i] == '!' {
+		i++
+	}
+	start := i
+	eq := -1
+	for ; i < len(s) && s[i] != ']'; i++ {
+		if s[i] == '[' {
+			return i, false
+		}
+		if s[i] == '=' && eq < 0 {
+			eq = i
+		}
+	}
+	if i >= len(s) {
+		return i, false
+	}
+	if eq < 0 {
+		if bad := scanTimezoneChars(s, start, i); bad >= 0 {
+			return bad, false
+		}
+		return i + 1, true
+	}
+	if bad := scanSuffixKey(s, start, eq); bad >= 0 {
+		return bad, false
+	}
+	if bad := scanSuffixValues(s, eq+1, i); bad >= 0 {
+		return bad, false
+	}
+	return i + 1, true
+}
+
+// scanTimezoneChars checks that s[start:end] is a non-empty run of
+// time-zone characters, returning the offending index or -1.
+func scanTimezoneChars(s string, start, end int) int {
+	if start == end {
+		return start
+	}
+	for i := start; i < end; i++ {
+		if c := s[i]; !isAlnum(c) && c != '.' && c != '_' && c != '+' && c != '/' && c != ':' && c != '-' {
+			return i
+		}
+	}
+	return -1
+}
+
+// scanSuffixKey checks s[start:end] against "[a-z_][a-z_0-9-]*", returning
+// the offending index or -1.
+func scanSuffixKey(s string, start, end int) int {
+	if start == end {
+		return start
+	}
+	for i := start; i < end; i++ {
+		c := s[i]
+		if ('a' <= c && c <= 'z') || c == '_' || (i > start && (isDigit(c) || c == '-')) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// scanSuffixValues checks s[start:end] against
+// "[A-Za-z0-9]+(-[A-Za-z0-9]+)*", returning the offending index or -1.
+func scanSuffixValues(s string, start, end int) int {
+	if start == end {
+		return start
+	}
+	for i := start; i < end; i++ {
+		c := s[i]
+		if isAlnum(c) {
+			continue
+		}
+		// A hyphen must separate two alphanumeric runs.
+		if c == '-' && i > start && s[i-1] != '-' && i+1 < end {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,176 @@
+package ixdtf
+
+import (
+	"testing"
+
+	"github.com/8beeeaaat/ixdtf/abnf"
+)
+
+// scanCorpus covers the TestValidate, TestParse, and TestFormat inputs plus
+// grammar edge cases; the scanner must agree with the ABNF regexp on all.
+func scanCorpus() []string {
+	return []string{
+		"",
+		"2022-01-08T00:14:07Z[Europe/London]",
+		"2022-07-08T00:14:07+00:00[!Europe/London]",
+		"2022-07-08T00:14:07+00:00[!Europe/London][Asia/Tokyo]",
+		"2022-07-08T00:14:07+05:00[!Etc/GMT+3]",
+		"2022-07-08T00:14:07+05:00[Etc/GMT+3]",
+		"2022-07-08T00:14:07-00:00[!Europe/London]",
+		"2022-07-08T00:14:07-00:00[Europe/London]",
+		"2022-07-08T00:14:07Z[!Europe/London]",
+		"2022-07-08T00:14:07Z[!knort=blargel]",
+		"2022-07-08T00:14:07Z[!u-ca=chinese][u-ca=japanese]",
+		"2022-07-08T00:14:07Z[Europe/London]",
+		"2022-07-08T00:14:07Z[Foo/Bar][Asia/Tokyo]",
+		"2022-07-08T00:14:07Z[u-ca=chinese][!u-ca=japanese]",
+		"2022-07-08T12:00:00Z[America/New_York]",
+		"2025-01-01T00:00:00+03:00[Africa/Asmera]",
+		"2025-01-01T00:00:00+05:00[Asia/Calcutta]",
+		"2025-01-01T00:00:00+05:00[Etc/GMT+5]",
+		"2025-01-01T00:00:00+05:00[Etc/GMT-5]",
+		"2025-01-01T00:00:00+05:30[Asia/Calcutta]",
+		"2025-01-01T00:00:00+09:00[!+05:30]",
+		"2025-01-01T00:00:00+09:00[!+09:00]",
+		"2025-01-01T00:00:00+09:00[+09:00]",
+		"2025-01-01T00:00:00-05:00[Etc/GMT+5]",
+		"2025-01-01T00:00:00Z[!Asia/Tokyo]",
+		"2025-01-01T00:00:00Z[!Foo/Bar]",
+		"2025-01-01T00:00:00Z[!]",
+		"2025-01-01T00:00:00Z[!_experiment=test]",
+		"2025-01-01T00:00:00Z[!key=invalid@value]",
+		"2025-01-01T00:00:00Z[!u-ca=gregory][t-invalid]",
+		"2025-01-01T00:00:00Z[!x-private=test]",
+		"2025-01-01T00:00:00Z[+09:00]",
+		"2025-01-01T00:00:00Z[+24:00]",
+		"2025-01-01T00:00:00Z[123key=value]",
+		"2025-01-01T00:00:00Z[=value]",
+		"2025-01-01T00:00:00Z[Asia/Tokyo][Europe/Paris]",
+		"2025-01-01T00:00:00Z[Foo/Bar]",
+		"2025-01-01T00:00:00Z[INVALID-KEY=value]",
+		"2025-01-01T00:00:00Z[No/SuchZone]",
+		"2025-01-01T00:00:00Z[]",
+		"2025-01-01T00:00:00Z[_experiment=test]",
+		"2025-01-01T00:00:00Z[_test=value]",
+		"2025-01-01T00:00:00Z[invalid@key=value]",
+		"2025-01-01T00:00:00Z[invalid@zone]",
+		"2025-01-01T00:00:00Z[invalid]",
+		"2025-01-01T00:00:00Z[key with spaces=value]",
+		"2025-01-01T00:00:00Z[key-with-hyphens=value]",
+		"2025-01-01T00:00:00Z[key=123]",
+		"2025-01-01T00:00:00Z[key=]",
+		"2025-01-01T00:00:00Z[key=abc123def]",
+		"2025-01-01T00:00:00Z[key=invalid@value]",
+		"2025-01-01T00:00:00Z[key=one][key=two]",
+		"2025-01-01T00:00:00Z[key=val_ue]",
+		"2025-01-01T00:00:00Z[key=value-with-hyphens]",
+		"2025-01-01T00:00:00Z[u-invalid-timezone]",
+		"2025-01-01T00:00:00Z[unclosed",
+		"2025-01-01T00:00:00Z[x-demo=yes]",
+		"2025-01-01T00:00:00Z[x-invalid-timezone]",
+		"2025-01-01T00:00:00Z[x-private=test]",
+		"2025-01-02T03:04:05.123456789Z",
+		"2025-01-02T03:04:05Z",
+		"2025-02-03T04:05:06+09:00[Asia/Tokyo]",
+		"2025-02-03T04:05:06+09:00[Asia/Tokyo][!u-ca=gregory]",
+		"2025-02-03T04:05:06Z[Asia/Tokyo]",
+		"2025-03-04T05:06:07Z[!t-format=iso][u-ca=hebrew]",
+		"2025-03-04T05:06:07Z[!u-ca=gregory]",
+		"2025-03-04T05:06:07Z[!u-ca=hoge]",
+		"2025-03-04T05:06:07Z[t-calendar=japanese][u-ca=gregory]",
+		"2025-03-04T05:06:07Z[u-ca=gregory]",
+		"2025-03-04T05:06:07Z[u-ca=hebrew][Asia/Tokyo]",
+		"2025-03-04T05:06:07Z[u-ca=hoge]",
+		"2025-06-01T12:00:00+09:00[America/New_York]",
+		"2025-06-07T08:09:10+01:00[Europe/Paris][!u-ca=gregory]",
+		"2025-12-25T15:30:45+01:00[CET]",
+		"[Asia/Tokyo]",
+		"not-a-date",
+		"not-a-date[unclosed",
+		"2025-01-01T00:00:00z",
+		"2025-01-01t00:00:00Z",
+		"2025-00-01T00:00:00Z",
+		"2025-12-31T23:59:60Z",
+		"2025-02-30T00:00:00Z",
+		"2025-01-01T00:00:00.Z",
+		"2025-01-01T00:00:00+0900",
+		"2025-01-01T00:00:00+09:00[]",
+		"2025-01-01T00:00:00Z[!!u-ca=gregory]",
+		"2025-01-01T00:00:00Z[u-ca=greg=ory]",
+		"2025-01-01T00:00:00Z[u-ca=-gregory]",
+		"2025-01-01T00:00:00Z[u-ca=gregory-]",
+		"2025-01-01T00:00:00Z[u-ca=gre--gory]",
+		"2025-01-01T00:00:00Z[a/b=c]",
+		"2025-01-01T00:00:00Z[Asia/Tokyo]x",
+		"2025-01-01T00:00:00Z[Asia[Tokyo]",
+		"2025-01-01T00:00:00Z[Asia/Tokyo]]",
+		"2025-01-01T00:00:00Z[!",
+		"2025-01-01T00:00:00Z[",
+		"2025-01-01T00:00:00Z[a=b][c=d][]",
+		"2025-01-01T00:00:00Z[+09:00:00]",
+		"2025-01-01T00:00:00Z[\x00]",
+		"2025-01-01T00:00:00Z[é=x]",
+	}
+}
+
+func TestScanDateTimeExtMatchesABNF(t *testing.T) {
+	t.Parallel()
+	for _, input := range scanCorpus() {
+		scanOK := scanDateTimeExt(input) < 0
+		abnfOK := abnf.AbnfDateTimeExt.ValidateDateTimeExt(input) == nil
+		if scanOK != abnfOK {
+			t.Errorf("scanDateTimeExt(%q) valid = %v, ABNF regexp valid = %v", input, scanOK, abnfOK)
+		}
+	}
+}
+
+func TestScanDateTimeExtOffendingIndex(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"2025-01-01T00:00:00Z", -1},
+		{"2025-13-01T00:00:00Z", 5},
+		{"2025-01-01 00:00:00Z", 10},
+		{"2025-01-01T00:00:00Z[invalid@key=value]", 28},
+		{"2025-01-01T00:00:00Z[key=val_ue]", 28},
+		{"2025-01-01T00:00:00Z[unclosed", 29},
+	}
+	for _, tc := range tests {
+		if got := scanDateTimeExt(tc.input); got != tc.want {
+			t.Errorf("scanDateTimeExt(%q) = %d, want %d", tc.input, got, tc.want)
+		}
+	}
+}
+
+func FuzzScanDateTimeExt(f *testing.F) {
+	for _, input := range scanCorpus() {
+		f.Add(input)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		scanOK := scanDateTimeExt(input) < 0
+		abnfOK := abnf.AbnfDateTimeExt.ValidateDateTimeExt(input) == nil
+		if scanOK != abnfOK {
+			t.Errorf("scanDateTimeExt(%q) valid = %v, ABNF regexp valid = %v", input, scanOK, abnfOK)
+		}
+	})
+}
+
+func BenchmarkScanDateTimeExt(b *testing.B) {
+	const input = "2025-06-07T08:09:10+01:00[Europe/Paris][!u-ca=gregory]"
+
+	b.Run("scanner", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = scanDateTimeExt(input)
+		}
+	})
+
+	b.Run("regexp", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = abnf.AbnfDateTimeExt.ValidateDateTimeExt(input)
+		}
+	})
+}
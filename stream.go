@@ -0,0 +1,129 @@
+package ixdtf
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Decoder reads newline-delimited IXDTF values from an input stream, such as
+// an append-only log with one timestamp per line.
+type Decoder struct {
+	scanner   *bufio.Scanner
+	strict    bool
+	opts      []ParseOption
+	skipBlank bool
+}
+
+// NewDecoder returns a Decoder that reads from r and parses each line with
+// Parse using strict and opts.
+func NewDecoder(r io.Reader, strict bool, opts ...ParseOption) *Decoder {
+	return &Decoder{
+		scanner: bufio.NewScanner(r),
+		strict:  strict,
+		opts:    opts,
+	}
+}
+
+// SkipBlankLines makes Decode skip empty lines instead of reporting them as
+// parse errors.
+func (d *Decoder) SkipBlankLines() {
+	d.skipBlank = true
+}
+
+// Decode parses the next line of the input. It returns io.EOF when the
+// input is exhausted, a read error from the underlying reader as-is, and a
+// *ParseError for a line that does not parse; decoding can continue after a
+// parse error.
+func (d *Decoder) Decode() (time.Time, *IXDTFExtensions, error) {
+	for d.scanner.Scan() {
+		line := d.scanner.Text()
+		if d.skipBlank && line == "" {
+			continue
+		}
+		return Parse(line, d.strict, d.opts...)
+	}
+	if err := d.scanner.Err(); err != nil {
+		return time.Time{}, nil, err
+	}
+	return time.Time{}, nil, io.EOF
+}
+
+// LineError is a failure reported by ValidateReader for one input line.
+type LineError struct {
+	// Line is the 1-based line number.
+	Line int
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e LineError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateReader runs Validate with strict and opts on every line read from
+// r and returns a LineError for each line that fails, in input order, or nil
+// when all lines are valid. Empty lines are skipped. A read error, including
+// a line longer than bufio.MaxScanTokenSize, ends the scan and is reported as
+// the last LineError, numbered after the last line read.
+func ValidateReader(r io.Reader, strict bool, opts ...ParseOption) []LineError {
+	var errs []LineError
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := Validate(scanner.Text(), strict, opts...); err != nil {
+			errs = append(errs, LineError{Line: line, Err: err})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, LineError{Line: line + 1, Err: err})
+	}
+	return errs
+}
+
+// Encoder writes newline-delimited IXDTF values to an output stream, the
+// counterpart of Decoder.
+type Encoder struct {
+	w    io.Writer
+	opts *formatOptions
+	buf  []byte
+}
+
+// NewEncoder returns an Encoder that writes to w, formatting every value with
+// opts.
+func NewEncoder(w io.Writer, opts ...FormatOption) *Encoder {
+	return &Encoder{w: w, opts: newFormatOptions(opts)}
+}
+
+// Encode writes t formatted as by Format, followed by a newline. When the
+// extensions fail validation, nothing is written.
+func (e *Encoder) Encode(t time.Time, ext *IXDTFExtensions) error {
+	return e.encode(t, ext, time.RFC3339)
+}
+
+// EncodeNano writes t formatted as by FormatNano, followed by a newline.
+// When the extensions fail validation, nothing is written.
+func (e *Encoder) EncodeNano(t time.Time, ext *IXDTFExtensions) error {
+	return e.encode(t, ext, time.RFC3339Nano)
+}
+
+// encode reuses the Encoder's buffer across calls, so a record costs no
+// string allocation.
+func (e *Encoder) encode(t time.Time, ext *IXDTFExtensions, layout string) error {
+	if err := validateForFormat(t, ext, e.opts); err != nil {
+		return err
+	}
+	e.buf = appendSuffix(e.buf[:0], t, ext, layout, e.opts)
+	e.buf = append(e.buf, '\n')
+	_, err := e.w.Write(e.buf)
+	return err
+}
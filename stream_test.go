@@ -0,0 +1,180 @@
+package ixdtf_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decodes each line until EOF", func(t *testing.T) {
+		t.Parallel()
+		input := "2025-01-02T03:04:05Z\n2025-02-03T04:05:06+09:00[Asia/Tokyo][u-ca=gregory]\n"
+		dec := ixdtf.NewDecoder(strings.NewReader(input), true)
+
+		got, ext, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode() #1 unexpected error: %v", err)
+		}
+		if got.Unix() != 1735787045 || ext.Location != nil {
+			t.Errorf("Decode() #1 = (%v, %+v)", got, ext)
+		}
+
+		_, ext, err = dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode() #2 unexpected error: %v", err)
+		}
+		if ext.Location == nil || ext.Location.String() != "Asia/Tokyo" || ext.Tags["u-ca"] != "gregory" {
+			t.Errorf("Decode() #2 extensions = %+v", ext)
+		}
+
+		if _, _, err = dec.Decode(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Decode() at end error = %v, want io.EOF", err)
+		}
+	})
+
+	t.Run("blank line is a parse error by default", func(t *testing.T) {
+		t.Parallel()
+		dec := ixdtf.NewDecoder(strings.NewReader("\n2025-01-02T03:04:05Z"), false)
+		var pe *ixdtf.ParseError
+		if _, _, err := dec.Decode(); !errors.As(err, &pe) {
+			t.Fatalf("Decode() blank line error = %v, want *ParseError", err)
+		}
+		if _, _, err := dec.Decode(); err != nil {
+			t.Fatalf("Decode() after parse error unexpected error: %v", err)
+		}
+	})
+
+	t.Run("blank lines are skipped when requested", func(t *testing.T) {
+		t.Parallel()
+		dec := ixdtf.NewDecoder(strings.NewReader("\n\n2025-01-02T03:04:05Z\n\n"), false)
+		dec.SkipBlankLines()
+		if _, _, err := dec.Decode(); err != nil {
+			t.Fatalf("Decode() unexpected error: %v", err)
+		}
+		if _, _, err := dec.Decode(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Decode() at end error = %v, want io.EOF", err)
+		}
+	})
+
+	t.Run("parse options apply to every line", func(t *testing.T) {
+		t.Parallel()
+		r := strings.NewReader("2025-03-04T05:06:07Z[U-CA=gregory]")
+		dec := ixdtf.NewDecoder(r, false, ixdtf.WithLowercaseKeys())
+		_, ext, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode() unexpected error: %v", err)
+		}
+		if ext.Tags["u-ca"] != "gregory" {
+			t.Errorf("Decode() tags = %v, want u-ca=gregory", ext.Tags)
+		}
+	})
+}
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes one value per line", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		enc := ixdtf.NewEncoder(&buf)
+		ts := time.Date(2025, 1, 2, 3, 4, 5, 123000000, time.UTC)
+		ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": "gregory"}})
+
+		if err := enc.Encode(ts, nil); err != nil {
+			t.Fatalf("Encode() unexpected error: %v", err)
+		}
+		if err := enc.EncodeNano(ts, ext); err != nil {
+			t.Fatalf("EncodeNano() unexpected error: %v", err)
+		}
+		want := "2025-01-02T03:04:05Z\n2025-01-02T03:04:05.123Z[u-ca=gregory]\n"
+		if buf.String() != want {
+			t.Errorf("output = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("invalid extensions write nothing", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		enc := ixdtf.NewEncoder(&buf)
+		ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": ""}})
+		if err := enc.Encode(time.Now(), ext); err == nil {
+			t.Fatal("Encode() expected error for empty tag value")
+		}
+		if buf.Len() != 0 {
+			t.Errorf("output = %q, want nothing written", buf.String())
+		}
+	})
+
+	t.Run("round-trips through Decoder", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		enc := ixdtf.NewEncoder(&buf)
+		ts := time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC)
+		for i := range 3 {
+			if err := enc.Encode(ts.Add(time.Duration(i)*time.Hour), nil); err != nil {
+				t.Fatalf("Encode() unexpected error: %v", err)
+			}
+		}
+		dec := ixdtf.NewDecoder(&buf, true)
+		for i := range 3 {
+			got, _, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("Decode() #%d unexpected error: %v", i, err)
+			}
+			if want := ts.Add(time.Duration(i) * time.Hour); !got.Equal(want) {
+				t.Errorf("Decode() #%d = %v, want %v", i, got, want)
+			}
+		}
+		if _, _, err := dec.Decode(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Decode() at end error = %v, want io.EOF", err)
+		}
+	})
+}
+
+func TestValidateReader(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		"2025-01-02T03:04:05Z",
+		"",
+		"not a timestamp",
+		"2025-01-02T03:04:05+09:00[Asia/Tokyo][u-ca=japanese]",
+		"2025-01-02T03:04:05+09:00[America/New_York]",
+		"",
+	}, "\n")
+
+	errs := ixdtf.ValidateReader(strings.NewReader(input), true)
+	if len(errs) != 2 || errs[0].Line != 3 || errs[1].Line != 5 {
+		t.Fatalf("ValidateReader() = %v, want failures on lines 3 and 5", errs)
+	}
+	if !errors.Is(errs[1], ixdtf.ErrTimezoneOffsetMismatch) {
+		t.Errorf("line 5 error = %v, want %v", errs[1], ixdtf.ErrTimezoneOffsetMismatch)
+	}
+	if got := errs[0].Error(); !strings.HasPrefix(got, "line 3: ") {
+		t.Errorf("Error() = %q, want a line 3 prefix", got)
+	}
+
+	// Non-strict mode accepts the inconsistent offset on line 5.
+	if errs := ixdtf.ValidateReader(strings.NewReader(input), false); len(errs) != 1 || errs[0].Line != 3 {
+		t.Errorf("non-strict ValidateReader() = %v, want a failure on line 3 only", errs)
+	}
+	if errs := ixdtf.ValidateReader(strings.NewReader("2025-01-02T03:04:05Z\r\n\r\n"), true); errs != nil {
+		t.Errorf("ValidateReader(CRLF) = %v, want nil", errs)
+	}
+
+	readErr := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("2025-01-02T03:04:05Z\n"), iotest.ErrReader(readErr))
+	errs = ixdtf.ValidateReader(r, true)
+	if len(errs) != 1 || errs[0].Line != 2 || !errors.Is(errs[0], readErr) {
+		t.Errorf("ValidateReader(failing reader) = %v, want %v on line 2", errs, readErr)
+	}
+}
@@ -15,10 +15,24 @@ import (
 type suffixParseState struct {
 	seenTimezone bool
 	seenTag      bool
+	tags         int // key=value elements seen, for WithMaxTags
 }
 
-func parseSuffix(s string, strict bool) (*IXDTFExtensions, error) {
-	ext := NewIXDTFExtensions(nil)
+// checkSuffixBounds rejects a suffix that exceeds the configured length or
+// bracket-group count. It is a single linear scan, so it bounds the work of
+// the later ABNF pattern match on untrusted input.
+func checkSuffixBounds(suffix string, opts *parseOptions) error {
+	if opts.maxSuffixLength > 0 && len(suffix) > opts.maxSuffixLength {
+		return ErrSuffixTooLong
+	}
+	if opts.maxSuffixElements > 0 && strings.Count(suffix, "[") > opts.maxSuffixElements {
+		return ErrTooManyTags
+	}
+	return nil
+}
+
+func parseSuffix(s string, opts *parseOptions) (*IXDTFExtensions, error) {
+	ext := &IXDTFExtensions{}
 	state := &suffixParseState{}
 
 	i := 0
@@ -38,7 +52,7 @@ func parseSuffix(s string, strict bool) (*IXDTFExtensions, error) {
 
 		// Parse the content between '[' and ']'
 		content := s[i+1 : j]
-		if err := parseSuffixElement(content, ext, strict, state); err != nil {
+		if err := parseSuffixElement(content, ext, opts, state); err != nil {
 			return ext, err
 		}
 
@@ -48,9 +62,12 @@ func parseSuffix(s string, strict bool) (*IXDTFExtensions, error) {
 	return ext, nil
 }
 
-func parseSuffixElement(content string, ext *IXDTFExtensions, strict bool, state *suffixParseState) error {
+// parseSuffixElement parses the content of one bracket. An empty "[]"
+// returns ErrEmptyBracket, distinct from the ErrInvalidSuffix that parseSuffix
+// returns for a bracket that is never closed.
+func parseSuffixElement(content string, ext *IXDTFExtensions, opts *parseOptions, state *suffixParseState) error {
 	if content == "" {
-		return ErrInvalidSuffix
+		return ErrEmptyBracket
 	}
 
 	critical := false
@@ -66,7 +83,11 @@ func parseSuffixElement(content string, ext *IXDTFExtensions, strict bool, state
 	// Extension tag (has '=') vs timezone name.
 	if eq := strings.IndexByte(content[startIdx:], '='); eq >= 0 {
 		state.seenTag = true
-		return handleExtensionTag(content, critical, startIdx, startIdx+eq, ext, strict)
+		state.tags++
+		if opts.maxTags > 0 && state.tags > opts.maxTags {
+			return ErrTooManyTags
+		}
+		return handleExtensionTag(content, critical, startIdx, startIdx+eq, ext, opts)
 	}
 
 	// Time-zone annotation.
@@ -81,20 +102,50 @@ func parseSuffixElement(content string, ext *IXDTFExtensions, strict bool, state
 	}
 	state.seenTimezone = true
 
-	loc, err := resolveZoneAnnotation(content[startIdx:])
+	name := content[startIdx:]
+	if opts.rejectMilitary && len(name) == 1 {
+		// No IANA zone is close to a single letter, so skip the suggestion.
+		return &TimezoneError{Name: name}
+	}
+	var cached bool
+	if opts.trace != nil {
+		_, cached = cachedLocationFor(name, opts.loader)
+	}
+	// Loading a zone may block on zoneinfo access, so honor cancellation
+	// first.
+	if opts.ctx != nil {
+		if err := opts.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	resolve := resolveZoneAnnotation
+	if opts.foldZoneCase {
+		resolve = resolveZoneAnnotationFold
+	}
+	loc, err := resolve(name, opts.loader)
 	if err != nil {
 		// RFC 9557 Section 4.1 permits a critical flag ("!") on a time-zone
 		// annotation, e.g. "[!Europe/London]" (Figures 1 and 2 in Section
 		// 3.4). A critical annotation MUST be processable (Section 3.3), so
 		// an unknown or invalid name is rejected even in non-strict mode;
 		// otherwise a non-strict parse ignores the annotation per RFC 9557.
-		if strict || critical {
-			return err
+		if opts.strict || critical {
+			return newTimezoneError(name)
+		}
+		if opts.trace != nil {
+			opts.trace.addf("zone %s unknown, ignored in non-strict mode", name)
+		}
+		if opts.recordDroppedZone {
+			ext.droppedZone = name
 		}
 		return nil
 	}
+	if opts.trace != nil {
+		traceZone(opts.trace, name, critical, cached)
+	}
 	ext.Location = loc
 	ext.CriticalLocation = critical
+	ext.ZoneResolved = true
 	return nil
 }
 
@@ -105,19 +156,38 @@ func handleExtensionTag(
 	critical bool,
 	startIdx, equalIndex int,
 	ext *IXDTFExtensions,
-	strict bool,
+	opts *parseOptions,
 ) error {
 	if equalIndex == startIdx || equalIndex == len(content)-1 {
 		return ErrInvalidExtension // empty key or value
 	}
-	if err := abnf.AbnfSuffixKey.ValidateSuffixKey(content[startIdx:equalIndex]); err != nil {
+	// Reject control bytes explicitly rather than relying on the ABNF
+	// patterns, so they can never leak into logs through a tag.
+	if hasControlByte(content) {
+		return ErrInvalidExtension
+	}
+	key := content[startIdx:equalIndex]
+	if exceedsLimit(len(key), opts.maxTagKeyLength) || exceedsLimit(len(content)-equalIndex-1, opts.maxTagValueLength) {
+		return ErrTagTooLong
+	}
+	if opts.lowercaseKeys && !opts.strict {
+		if lowered := strings.ToLower(key); lowered != key {
+			if opts.trace != nil {
+				opts.trace.addf("key %s lowercased to %s", key, lowered)
+			}
+			key = lowered
+			ext.KeysLowercased = true
+		}
+	}
+	if err := abnf.AbnfSuffixKey.ValidateSuffixKey(key); err != nil {
 		return err
 	}
 	if err := isValidSuffixValue(content[equalIndex+1:]); err != nil {
 		return err
 	}
-
-	key := content[startIdx:equalIndex]
+	if opts.numberingSystem && key == ExtensionUnicodeNumberingSystem && !IsNumberingSystem(content[equalIndex+1:]) {
+		return ErrUnknownNumberingSystem
+	}
 
 	// RFC 9557 Section 3.3: for elective duplicates the first occurrence
 	// wins, but a duplicate suffix key involving a critical flag on either
@@ -126,6 +196,15 @@ func handleExtensionTag(
 		if critical || ext.Critical[key] {
 			return ErrCriticalExtension
 		}
+		if opts.rejectDuplicates {
+			return ErrDuplicateKey
+		}
+		if opts.trace != nil {
+			opts.trace.addf("duplicate key %s ignored, first occurrence wins", key)
+		}
+		if opts.collectDuplicates {
+			ext.Duplicates = append(ext.Duplicates, Tag{Key: key, Value: content[equalIndex+1:]})
+		}
 		return nil
 	}
 	value := content[equalIndex+1:]
@@ -135,20 +214,62 @@ func handleExtensionTag(
 		}
 		// RFC 9557 Section 3.3: a recipient MUST treat the string as
 		// erroneous when it cannot process a critical suffix key. In strict
-		// mode this library acts as the recipient and only understands
-		// "u-ca"; in non-strict mode processing is delegated to the caller
-		// via the Critical map.
-		if strict && key != ExtensionUnicodeCalendar {
-			return ErrCriticalExtension
+		// mode this library acts as the recipient and understands the keys
+		// in the configured registry; in non-strict mode processing is
+		// delegated to the caller via the Critical map.
+		if opts.strict {
+			if err := opts.extensions.process(key, value); err != nil {
+				return err
+			}
 		}
 	}
+	// The maps are created on the first tag, so a suffix with only a
+	// time-zone annotation allocates none.
+	if ext.Tags == nil {
+		ext.Tags = make(map[string]string)
+	}
 	ext.Tags[key] = value
 	if critical {
+		if ext.Critical == nil {
+			ext.Critical = make(map[string]bool)
+		}
 		ext.Critical[key] = true
 	}
+	if opts.trace != nil {
+		opts.trace.addf("tag %s=%s (critical=%t)", key, value, critical)
+	}
 	return nil
 }
 
+// traceZone records how a time-zone annotation was resolved.
+func traceZone(trace *ParseTrace, name string, critical, cached bool) {
+	switch {
+	case isOffsetLocationName(name):
+		trace.addf("zone %s resolved as numeric offset (critical=%t)", name, critical)
+	case cached:
+		trace.addf("zone %s resolved from cache (critical=%t)", name, critical)
+	default:
+		trace.addf("zone %s loaded (critical=%t)", name, critical)
+	}
+}
+
+// exceedsLimit reports whether n is over limit, where a limit of zero or less
+// means unbounded.
+func exceedsLimit(n, limit int) bool {
+	return limit > 0 && n > limit
+}
+
+// hasControlByte reports whether s contains an ASCII control character
+// (below 0x20, including NUL) or DEL (0x7f).
+func hasControlByte(s string) bool {
+	for i := range len(s) {
+		if c := s[i]; c < 0x20 || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
 func isValidSuffixValue(value string) error {
 	if value == "" {
 		return nil
@@ -11,7 +11,7 @@ func TestParseSuffix(t *testing.T) {
 		t.Parallel()
 		// RFC 9557 Section 4.1 permits a "!" flag on a time-zone annotation.
 		ext := NewIXDTFExtensions(nil)
-		if err := parseSuffixElement("!Asia/Tokyo", ext, false, &suffixParseState{}); err != nil {
+		if err := parseSuffixElement("!Asia/Tokyo", ext, newParseOptions(false, nil), &suffixParseState{}); err != nil {
 			t.Fatalf("expected critical timezone to be accepted, got %v", err)
 		}
 		if ext.Location == nil || ext.Location.String() != "Asia/Tokyo" {
@@ -27,14 +27,15 @@ func TestParseSuffix(t *testing.T) {
 		// A critical annotation MUST be processable (Section 3.3), so an
 		// unknown name is an error even in non-strict mode.
 		ext := NewIXDTFExtensions(nil)
-		if err := parseSuffixElement("!Foo/Bar", ext, false, &suffixParseState{}); !errors.Is(err, ErrInvalidTimezone) {
+		err := parseSuffixElement("!Foo/Bar", ext, newParseOptions(false, nil), &suffixParseState{})
+		if !errors.Is(err, ErrInvalidTimezone) {
 			t.Fatalf("expected ErrInvalidTimezone for critical unknown timezone, got %v", err)
 		}
 	})
 
 	t.Run("missing brackets", func(t *testing.T) {
 		t.Parallel()
-		if _, err := parseSuffix("invalid", false); !errors.Is(err, ErrInvalidSuffix) {
+		if _, err := parseSuffix("invalid", newParseOptions(false, nil)); !errors.Is(err, ErrInvalidSuffix) {
 			t.Fatalf("parseSuffix should fail for missing brackets, got %v", err)
 		}
 	})
@@ -52,7 +53,8 @@ func TestSuffixValidation(t *testing.T) {
 	t.Run("empty key", func(t *testing.T) {
 		t.Parallel()
 		ext := NewIXDTFExtensions(nil)
-		if err := parseSuffixElement("=val", ext, false, &suffixParseState{}); !errors.Is(err, ErrInvalidExtension) {
+		err := parseSuffixElement("=val", ext, newParseOptions(false, nil), &suffixParseState{})
+		if !errors.Is(err, ErrInvalidExtension) {
 			t.Fatalf("expected ErrInvalidExtension for empty key, got %v", err)
 		}
 	})
@@ -60,7 +62,8 @@ func TestSuffixValidation(t *testing.T) {
 	t.Run("empty value", func(t *testing.T) {
 		t.Parallel()
 		ext := NewIXDTFExtensions(nil)
-		if err := parseSuffixElement("key=", ext, false, &suffixParseState{}); !errors.Is(err, ErrInvalidExtension) {
+		err := parseSuffixElement("key=", ext, newParseOptions(false, nil), &suffixParseState{})
+		if !errors.Is(err, ErrInvalidExtension) {
 			t.Fatalf("expected ErrInvalidExtension for empty value, got %v", err)
 		}
 	})
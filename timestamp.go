@@ -0,0 +1,338 @@
+package ixdtf
+
+import (
+	"encoding/binary"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Timestamp pairs a time with its IXDTF extensions so the two can travel
+// together through encoding interfaces. The zero value is the zero time with
+// no extensions.
+type Timestamp struct {
+	Time       time.Time
+	Extensions *IXDTFExtensions
+}
+
+// In returns ts with the instant shifted to loc and loc as the time-zone
+// annotation, following the rules of InLocation. Tags, critical flags, and
+// CriticalLocation are kept; the returned extensions are a copy, so ts is
+// unchanged. Like time.Time.In, In panics if loc is nil.
+func (ts Timestamp) In(loc *time.Location) Timestamp {
+	if loc == nil {
+		panic("ixdtf: Timestamp.In: nil location")
+	}
+	t, ext := shiftLocation(ts.Time, ts.Extensions, loc)
+	return Timestamp{Time: t, Extensions: ext}
+}
+
+// UTC returns ts.In(time.UTC). Its time-zone annotation is "UTC".
+func (ts Timestamp) UTC() Timestamp {
+	return ts.In(time.UTC)
+}
+
+// String implements fmt.Stringer using FormatNano. When the extensions do not
+// format, it falls back to ts.Time in RFC 3339 format with nanoseconds.
+func (ts Timestamp) String() string {
+	s, err := FormatNano(ts.Time, ts.Extensions)
+	if err != nil {
+		return ts.Time.Format(time.RFC3339Nano)
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler using FormatNano.
+func (ts Timestamp) MarshalText() ([]byte, error) {
+	s, err := FormatNano(ts.Time, ts.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using a non-strict Parse.
+// An empty text sets ts to the zero value.
+func (ts *Timestamp) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*ts = Timestamp{}
+		return nil
+	}
+	t, ext, err := Parse(string(text), false)
+	if err != nil {
+		return err
+	}
+	*ts = Timestamp{Time: t, Extensions: ext}
+	return nil
+}
+
+// timestampBinaryVersion is the first byte of the MarshalBinary encoding.
+const timestampBinaryVersion byte = 1
+
+// Flags stored after the version byte.
+const (
+	binaryFlagExtensions byte = 1 << iota
+	binaryFlagCriticalLocation
+	binaryFlagUnknownOffset
+	binaryFlagZuluOffset
+	binaryFlagKeysLowercased
+	binaryFlagOffset
+	binaryFlagZoneResolved
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a
+// version byte, a flags byte, the length-prefixed time.Time binary form, and,
+// when Extensions is set, the length-prefixed location name and dropped zone
+// name, the varint Offset when it is set, counted, length-prefixed Tags and
+// Critical entries in sorted key order, and the counted Duplicates in input
+// order. The boolean fields of Extensions travel in the flags byte.
+// Time.MarshalBinary keeps only the offset of ts.Time, not its location.
+//
+// A Location whose name UnmarshalBinary could not load again, such as an
+// unnamed fixed zone or one from a custom loader, fails with a
+// *TimezoneError wrapping ErrInvalidTimezone.
+func (ts Timestamp) MarshalBinary() ([]byte, error) {
+	tb, err := ts.Time.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var flags byte
+	ext := ts.Extensions
+	var name string
+	if ext != nil && ext.Location != nil {
+		name = ext.Location.String()
+		if _, err := resolveZoneAnnotation(name, stdLocationLoader{}); err != nil {
+			return nil, newTimezoneError(name)
+		}
+	}
+	if ext != nil {
+		flags |= binaryFlagExtensions
+		flags |= binaryFlagsOf(ext)
+	}
+	b := []byte{timestampBinaryVersion, flags}
+	b = appendBinaryString(b, string(tb))
+	if ext == nil {
+		return b, nil
+	}
+	b = appendBinaryString(b, name)
+	b = appendBinaryString(b, ext.droppedZone)
+	if ext.Offset != nil {
+		b = binary.AppendVarint(b, int64(*ext.Offset))
+	}
+	b = binary.AppendUvarint(b, uint64(len(ext.Tags)))
+	for _, key := range slices.Sorted(maps.Keys(ext.Tags)) {
+		b = appendBinaryString(b, key)
+		b = appendBinaryString(b, ext.Tags[key])
+	}
+	b = binary.AppendUvarint(b, uint64(len(ext.Critical)))
+	for _, key := range slices.Sorted(maps.Keys(ext.Critical)) {
+		b = appendBinaryString(b, key)
+		b = append(b, boolByte(ext.Critical[key]))
+	}
+	b = binary.AppendUvarint(b, uint64(len(ext.Duplicates)))
+	for _, tag := range ext.Duplicates {
+		b = appendBinaryString(b, tag.Key)
+		b = appendBinaryString(b, tag.Value)
+		b = append(b, boolByte(tag.Critical))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the encoding
+// produced by MarshalBinary. A truncated, oversized, or otherwise malformed
+// blob fails with ErrInvalidBinaryEncoding, and a location name that no
+// longer resolves fails with ErrInvalidTimezone; ts is left unchanged on
+// error.
+func (ts *Timestamp) UnmarshalBinary(data []byte) error {
+	r := binaryReader{b: data}
+	if version := r.byte(); version != timestampBinaryVersion {
+		return ErrInvalidBinaryEncoding
+	}
+	flags := r.byte()
+	var t time.Time
+	if tb := r.string(); r.ok() {
+		if err := t.UnmarshalBinary([]byte(tb)); err != nil {
+			return ErrInvalidBinaryEncoding
+		}
+		// time.Time.UnmarshalBinary yields time.Local when the offset matches
+		// the machine's zone, which Format would annotate with the system
+		// zone name; keep the decoded offset unnamed instead.
+		if t.Location() == time.Local {
+			_, offset := t.Zone()
+			t = t.In(time.FixedZone("", offset))
+		}
+	}
+	if flags&binaryFlagExtensions == 0 {
+		if !r.done() {
+			return ErrInvalidBinaryEncoding
+		}
+		*ts = Timestamp{Time: t}
+		return nil
+	}
+
+	ext := NewIXDTFExtensions(&NewIXDTFExtensionsArgs{
+		CriticalLocation: flags&binaryFlagCriticalLocation != 0,
+	})
+	ext.UnknownOffset = flags&binaryFlagUnknownOffset != 0
+	ext.ZuluOffset = flags&binaryFlagZuluOffset != 0
+	ext.KeysLowercased = flags&binaryFlagKeysLowercased != 0
+	ext.ZoneResolved = flags&binaryFlagZoneResolved != 0
+	name := r.string()
+	ext.droppedZone = r.string()
+	if flags&binaryFlagOffset != 0 {
+		offset := r.offset()
+		ext.Offset = &offset
+	}
+	for n := r.count(); n > 0 && r.ok(); n-- {
+		key := r.string()
+		ext.Tags[key] = r.string()
+	}
+	for n := r.count(); n > 0 && r.ok(); n-- {
+		key := r.string()
+		ext.Critical[key] = r.bool()
+	}
+	for n := r.count(); n > 0 && r.ok(); n-- {
+		tag := Tag{Key: r.string(), Value: r.string()}
+		tag.Critical = r.bool()
+		ext.Duplicates = append(ext.Duplicates, tag)
+	}
+	if !r.done() {
+		return ErrInvalidBinaryEncoding
+	}
+	if name != "" {
+		loc, err := resolveZoneAnnotation(name, stdLocationLoader{})
+		if err != nil {
+			return err
+		}
+		ext.Location = loc
+	}
+	*ts = Timestamp{Time: t, Extensions: ext}
+	return nil
+}
+
+// binaryFlagsOf returns the flags recording the boolean fields of ext and
+// whether its Offset is set.
+func binaryFlagsOf(ext *IXDTFExtensions) byte {
+	var flags byte
+	if ext.CriticalLocation {
+		flags |= binaryFlagCriticalLocation
+	}
+	if ext.UnknownOffset {
+		flags |= binaryFlagUnknownOffset
+	}
+	if ext.ZuluOffset {
+		flags |= binaryFlagZuluOffset
+	}
+	if ext.KeysLowercased {
+		flags |= binaryFlagKeysLowercased
+	}
+	if ext.Offset != nil {
+		flags |= binaryFlagOffset
+	}
+	if ext.ZoneResolved {
+		flags |= binaryFlagZoneResolved
+	}
+	return flags
+}
+
+func appendBinaryString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func boolByte(v bool) byte {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// binaryReader decodes the MarshalBinary encoding. Once a read runs past the
+// end of the input, every later read returns a zero value and ok reports
+// false.
+type binaryReader struct {
+	b   []byte
+	bad bool
+}
+
+func (r *binaryReader) ok() bool {
+	return !r.bad
+}
+
+// done reports whether the input was consumed exactly.
+func (r *binaryReader) done() bool {
+	return !r.bad && len(r.b) == 0
+}
+
+func (r *binaryReader) byte() byte {
+	if r.bad || len(r.b) == 0 {
+		r.bad = true
+		return 0
+	}
+	c := r.b[0]
+	r.b = r.b[1:]
+	return c
+}
+
+// bool reads a byte that must be 0 or 1.
+func (r *binaryReader) bool() bool {
+	switch r.byte() {
+	case 0:
+		return false
+	case 1:
+		return true
+	default:
+		r.bad = true
+		return false
+	}
+}
+
+// count reads an entry count, rejecting one that cannot fit in the
+// remaining input so a corrupt blob cannot force a huge loop.
+func (r *binaryReader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.b)) {
+		r.bad = true
+		return 0
+	}
+	return int(n)
+}
+
+func (r *binaryReader) string() string {
+	n := r.uvarint()
+	if r.bad || n > uint64(len(r.b)) {
+		r.bad = true
+		return ""
+	}
+	s := string(r.b[:n])
+	r.b = r.b[n:]
+	return s
+}
+
+// offset reads a signed UTC offset in seconds, rejecting one beyond a day
+// in either direction.
+func (r *binaryReader) offset() int {
+	if r.bad {
+		return 0
+	}
+	v, n := binary.Varint(r.b)
+	if n <= 0 || v <= -secondsPerDay || v >= secondsPerDay {
+		r.bad = true
+		return 0
+	}
+	r.b = r.b[n:]
+	return int(v)
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	if r.bad {
+		return 0
+	}
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.bad = true
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
@@ -0,0 +1,340 @@
+package ixdtf_test
+
+import (
+	"encoding"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+var (
+	_ encoding.TextMarshaler     = ixdtf.Timestamp{}
+	_ encoding.TextUnmarshaler   = (*ixdtf.Timestamp)(nil)
+	_ encoding.BinaryMarshaler   = ixdtf.Timestamp{}
+	_ encoding.BinaryUnmarshaler = (*ixdtf.Timestamp)(nil)
+)
+
+func TestTimestampText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-02-03T04:05:06.5+09:00[Asia/Tokyo][!u-ca=gregory]"
+		var ts ixdtf.Timestamp
+		if err := ts.UnmarshalText([]byte(input)); err != nil {
+			t.Fatalf("UnmarshalText() unexpected error: %v", err)
+		}
+		if ts.Extensions.Location == nil || ts.Extensions.Location.String() != "Asia/Tokyo" {
+			t.Errorf("UnmarshalText() location = %v, want Asia/Tokyo", ts.Extensions.Location)
+		}
+		got, err := ts.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() unexpected error: %v", err)
+		}
+		if string(got) != input {
+			t.Errorf("MarshalText() = %q, want %q", got, input)
+		}
+	})
+
+	t.Run("empty text is the zero value", func(t *testing.T) {
+		t.Parallel()
+		ts := ixdtf.Timestamp{Time: time.Now()}
+		if err := ts.UnmarshalText(nil); err != nil {
+			t.Fatalf("UnmarshalText() unexpected error: %v", err)
+		}
+		if !ts.Time.IsZero() || ts.Extensions != nil {
+			t.Errorf("UnmarshalText(empty) = %+v, want zero value", ts)
+		}
+	})
+
+	t.Run("invalid text", func(t *testing.T) {
+		t.Parallel()
+		var ts ixdtf.Timestamp
+		var pe *ixdtf.ParseError
+		if err := ts.UnmarshalText([]byte("not a time")); !errors.As(err, &pe) {
+			t.Fatalf("UnmarshalText() error = %v, want *ParseError", err)
+		}
+	})
+
+	t.Run("invalid extensions fail to marshal", func(t *testing.T) {
+		t.Parallel()
+		ts := ixdtf.Timestamp{
+			Time:       time.Now(),
+			Extensions: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"u-ca": ""}}),
+		}
+		if _, err := ts.MarshalText(); err == nil {
+			t.Fatal("MarshalText() expected error for empty tag value")
+		}
+	})
+}
+
+func TestTimestampIn(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("America/New_York unavailable: %v", err)
+	}
+	const input = "2025-01-02T12:04:05+09:00[Asia/Tokyo][!u-ca=gregory]"
+	tm, ext := ixdtf.MustParseStrict(input)
+	ts := ixdtf.Timestamp{Time: tm, Extensions: ext}
+
+	tests := []struct {
+		name string
+		got  ixdtf.Timestamp
+		want string
+	}{
+		{"In", ts.In(newYork), "2025-01-01T22:04:05-05:00[America/New_York][!u-ca=gregory]"},
+		{"UTC", ts.UTC(), "2025-01-02T03:04:05Z[UTC][!u-ca=gregory]"},
+		{"unnamed zone", ts.In(time.FixedZone("", 3600)), "2025-01-02T04:04:05+01:00[!u-ca=gregory]"},
+		{"no extensions", ixdtf.Timestamp{Time: tm}.In(newYork), "2025-01-01T22:04:05-05:00[America/New_York]"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.got.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+			if !tc.got.Time.Equal(tm) {
+				t.Errorf("instant = %v, want %v", tc.got.Time, tm)
+			}
+		})
+	}
+
+	shifted := ts.In(newYork)
+	shifted.Extensions.Tags["u-ca"] = "japanese"
+	if got := ts.String(); got != input {
+		t.Errorf("original String() = %q after modifying the shifted copy, want %q", got, input)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("In(nil) did not panic")
+		}
+	}()
+	_ = ts.In(nil)
+}
+
+func TestTimestampStringFallback(t *testing.T) {
+	t.Parallel()
+
+	ts := ixdtf.Timestamp{
+		Time:       time.Date(2025, 1, 2, 3, 4, 5, 600000000, time.UTC),
+		Extensions: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: map[string]string{"Bad_Key": "x"}}),
+	}
+	if got, want := ts.String(), "2025-01-02T03:04:05.6Z"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestTimestampBinary(t *testing.T) {
+	t.Parallel()
+
+	tokyo, _, _ := getTestTimezones()
+	full := ixdtf.Timestamp{
+		Time: time.Date(2025, 2, 3, 4, 5, 6, 7, tokyo),
+		Extensions: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{
+			Location:         tokyo,
+			CriticalLocation: true,
+			Tags:             map[string]string{"u-ca": "gregory", "a-key": "value"},
+			Critical:         map[string]bool{"u-ca": true, "a-key": false},
+		}),
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		for _, in := range []ixdtf.Timestamp{full, {Time: full.Time}, {}} {
+			data, err := in.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() unexpected error: %v", err)
+			}
+			var out ixdtf.Timestamp
+			if err = out.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() unexpected error: %v", err)
+			}
+			if !out.Time.Equal(in.Time) {
+				t.Errorf("time = %v, want %v", out.Time, in.Time)
+			}
+			if in.Extensions == nil {
+				if out.Extensions != nil {
+					t.Errorf("extensions = %+v, want nil", out.Extensions)
+				}
+				continue
+			}
+			if out.Extensions.Location.String() != in.Extensions.Location.String() ||
+				out.Extensions.CriticalLocation != in.Extensions.CriticalLocation ||
+				!maps.Equal(out.Extensions.Tags, in.Extensions.Tags) ||
+				!maps.Equal(out.Extensions.Critical, in.Extensions.Critical) {
+				t.Errorf("extensions = %+v, want %+v", out.Extensions, in.Extensions)
+			}
+		}
+	})
+
+	t.Run("parsed offset forms", func(t *testing.T) {
+		t.Parallel()
+		zulu := []ixdtf.FormatOption{ixdtf.WithPreserveZuluForm()}
+		source := []ixdtf.FormatOption{ixdtf.WithPreserveSourceOffset()}
+		tests := []struct {
+			input string
+			opts  []ixdtf.FormatOption
+		}{
+			{input: "2025-01-02T03:04:05-00:00"},
+			{input: "2025-01-02T03:04:05-00:00[Europe/Paris]"},
+			{input: "2025-01-02T03:04:05Z[Asia/Tokyo]", opts: zulu},
+			{input: "2025-01-02T03:04:05Z[Asia/Tokyo]", opts: source},
+			{input: "2025-01-02T03:04:05+01:00[Asia/Tokyo]", opts: source},
+		}
+		for _, tt := range tests {
+			parsed, ext, err := ixdtf.Parse(tt.input, false)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			data, err := ixdtf.Timestamp{Time: parsed, Extensions: ext}.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() unexpected error: %v", err)
+			}
+			var out ixdtf.Timestamp
+			if err = out.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() unexpected error: %v", err)
+			}
+			got := out.Extensions
+			if got.UnknownOffset != ext.UnknownOffset || got.ZuluOffset != ext.ZuluOffset ||
+				got.Offset == nil || *got.Offset != *ext.Offset {
+				t.Errorf("%q: extensions = %+v, want %+v", tt.input, got, ext)
+			}
+			formatted, err := ixdtf.Format(out.Time, got, tt.opts...)
+			if err != nil || formatted != tt.input {
+				t.Errorf("Format(decoded %q) = %q, %v, want the input", tt.input, formatted, err)
+			}
+		}
+	})
+
+	t.Run("parse-only fields", func(t *testing.T) {
+		t.Parallel()
+		collect := []ixdtf.ParseOption{ixdtf.WithCollectDuplicates()}
+		record := []ixdtf.ParseOption{ixdtf.WithRecordDroppedZone()}
+		tests := []struct {
+			input string
+			opts  []ixdtf.ParseOption
+		}{
+			{input: "2025-01-02T03:04:05Z[Asia/Tokyo][a=1][a=2][a=3]", opts: collect},
+			{input: "2025-01-02T03:04:05Z[No/SuchZone]", opts: record},
+		}
+		for _, tt := range tests {
+			parsed, ext, err := ixdtf.Parse(tt.input, false, tt.opts...)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			data, err := ixdtf.Timestamp{Time: parsed, Extensions: ext}.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() unexpected error: %v", err)
+			}
+			var out ixdtf.Timestamp
+			if err = out.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() unexpected error: %v", err)
+			}
+			got := out.Extensions
+			if got.ZoneResolved != ext.ZoneResolved || got.TimeZoneName() != ext.TimeZoneName() ||
+				!slices.Equal(got.Duplicates, ext.Duplicates) {
+				t.Errorf("%q: extensions = %#v, want %#v", tt.input, got, ext)
+			}
+		}
+	})
+
+	t.Run("unloadable location fails to marshal", func(t *testing.T) {
+		t.Parallel()
+		for _, loc := range []*time.Location{time.FixedZone("", 3600), time.FixedZone("No/SuchZone", 0)} {
+			in := ixdtf.Timestamp{
+				Time:       full.Time,
+				Extensions: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Location: loc}),
+			}
+			var tzErr *ixdtf.TimezoneError
+			if _, err := in.MarshalBinary(); !errors.As(err, &tzErr) || !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+				t.Errorf("MarshalBinary(location %q) error = %v, want a TimezoneError", loc, err)
+			}
+		}
+	})
+
+	t.Run("malformed blobs", func(t *testing.T) {
+		t.Parallel()
+		data, err := full.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() unexpected error: %v", err)
+		}
+		for n := range len(data) {
+			var ts ixdtf.Timestamp
+			if err = ts.UnmarshalBinary(data[:n]); !errors.Is(err, ixdtf.ErrInvalidBinaryEncoding) {
+				t.Fatalf("UnmarshalBinary(truncated to %d) error = %v, want ErrInvalidBinaryEncoding", n, err)
+			}
+		}
+		var ts ixdtf.Timestamp
+		if err = ts.UnmarshalBinary(append(data, 0)); !errors.Is(err, ixdtf.ErrInvalidBinaryEncoding) {
+			t.Fatalf("UnmarshalBinary(trailing byte) error = %v, want ErrInvalidBinaryEncoding", err)
+		}
+	})
+}
+
+// TestTimestampBinaryIgnoresLocal does not call t.Parallel because it
+// replaces time.Local, which concurrently running tests would observe.
+func TestTimestampBinaryIgnoresLocal(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo unavailable: %v", err)
+	}
+	systemLocal := time.Local
+	time.Local = tokyo
+	t.Cleanup(func() { time.Local = systemLocal })
+
+	// An offset that matches the local zone must not pick up its name.
+	const want = "2025-01-02T03:04:05+09:00"
+	in := ixdtf.Timestamp{
+		Time:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("", 9*60*60)),
+		Extensions: ixdtf.NewIXDTFExtensions(nil),
+	}
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() unexpected error: %v", err)
+	}
+	var out ixdtf.Timestamp
+	if err = out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() unexpected error: %v", err)
+	}
+	if out.Time.Location() == time.Local {
+		t.Errorf("UnmarshalBinary() location = time.Local, want a fixed zone")
+	}
+	if got, err := ixdtf.Format(out.Time, out.Extensions); err != nil || got != want {
+		t.Errorf("Format(decoded) = %q, %v, want %q", got, err, want)
+	}
+}
+
+func FuzzTimestampUnmarshalBinary(f *testing.F) {
+	tokyo, _, _ := getTestTimezones()
+	seed, err := ixdtf.Timestamp{
+		Time:       time.Date(2025, 2, 3, 4, 5, 6, 7, tokyo),
+		Extensions: ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Location: tokyo}),
+	}.MarshalBinary()
+	if err != nil {
+		f.Fatalf("MarshalBinary() unexpected error: %v", err)
+	}
+	f.Add(seed)
+	f.Add([]byte{1, 1, 0xff, 0xff, 0xff, 0xff, 0x0f})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var ts ixdtf.Timestamp
+		if ts.UnmarshalBinary(data) != nil {
+			return
+		}
+		again, err := ts.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() after successful UnmarshalBinary: %v", err)
+		}
+		var ts2 ixdtf.Timestamp
+		if err = ts2.UnmarshalBinary(again); err != nil {
+			t.Fatalf("UnmarshalBinary(MarshalBinary()) unexpected error: %v", err)
+		}
+	})
+}
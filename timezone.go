@@ -2,7 +2,9 @@ package ixdtf
 
 import (
 	"errors"
+	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,15 +21,36 @@ type TimezoneConsistencyResult struct {
 	OriginalOffset int
 	// ExpectedOffset is the expected offset for the timezone.
 	ExpectedOffset int
-	// Timezone is the timezone identifier that was checked.
+	// Timezone is the timezone identifier that was checked, as declared: the
+	// IANA name or numeric offset of the annotation, so a backward link such
+	// as "Asia/Calcutta" is reported as written even though Location holds
+	// the resolved zone. It is empty when there was no zone to check.
 	Timezone string
-	// Skipped indicates if the consistency check was skipped.
-	//
-	// Deprecated: no checks are skipped anymore; the field is retained for
-	// API compatibility and is always false.
+	// Skipped reports that the offset comparison was skipped because the
+	// RFC 3339 portion used the unknown local offset "-00:00" or its "Z"
+	// equivalent (RFC 9557 Section 2.2), which asserts no local offset to
+	// compare. IsConsistent is then true, also in strict mode, and
+	// ExpectedOffset is the zone's offset used to resolve local time.
 	Skipped bool
 }
 
+// CheckTimezoneConsistencyAt compares the UTC offset t carries with the
+// offset loc has at the reference instant ref, rather than at t itself as
+// Parse does (RFC 9557 Section 3.4). Passing ref == t gives the Parse check;
+// a different ref answers what the offset should have been at another time,
+// such as a submission time when the clock behind t is suspect. An
+// inconsistency is reported through IsConsistent, not as an error. A loc
+// that names an unknown zone returns a *TimezoneError matching
+// ErrInvalidTimezone, and a nil loc is consistent.
+func CheckTimezoneConsistencyAt(t time.Time, loc *time.Location, ref time.Time) (*TimezoneConsistencyResult, error) {
+	if loc != nil {
+		if _, err := resolveLocation(loc, stdLocationLoader{}); err != nil {
+			return nil, newTimezoneError(loc.String())
+		}
+	}
+	return checkTimezoneConsistencyAt(t, ref, loc, false, false, stdLocationLoader{})
+}
+
 // checkTimezoneConsistency checks if the timezone offset matches the IANA timezone.
 // If strict is true, returns an error when offsets don't match.
 // Returns consistency information and an error for timezone loading failures or strict mode mismatches.
@@ -36,6 +59,20 @@ func checkTimezoneConsistency(
 	location *time.Location,
 	strict bool,
 	offsetUnknown bool,
+	loader LocationLoader,
+) (*TimezoneConsistencyResult, error) {
+	return checkTimezoneConsistencyAt(timestamp, timestamp, location, strict, offsetUnknown, loader)
+}
+
+// checkTimezoneConsistencyAt is checkTimezoneConsistency with the expected
+// offset taken at ref instead of at timestamp.
+func checkTimezoneConsistencyAt(
+	timestamp time.Time,
+	ref time.Time,
+	location *time.Location,
+	strict bool,
+	offsetUnknown bool,
+	loader LocationLoader,
 ) (*TimezoneConsistencyResult, error) {
 	result := &TimezoneConsistencyResult{
 		Location: location,
@@ -45,7 +82,8 @@ func checkTimezoneConsistency(
 		result.IsConsistent = true // No timezone means no inconsistency
 		return result, nil
 	}
-	loc, err := resolveLocation(location)
+	result.Timezone = location.String()
+	loc, err := resolveLocation(location, loader)
 	if err != nil {
 		// In non-strict mode, ignore unknown timezone errors per RFC 9557
 		if !strict {
@@ -65,27 +103,31 @@ func checkTimezoneConsistency(
 	// See https://www.rfc-editor.org/rfc/rfc9557#section-3.4 (Figure 2).
 	if offsetUnknown {
 		_, result.OriginalOffset = timestamp.Zone()
-		_, result.ExpectedOffset = timestamp.In(loc).Zone()
+		_, result.ExpectedOffset = ref.In(loc).Zone()
 		result.IsConsistent = true
+		result.Skipped = true
 		return result, nil
 	}
 
-	// Get the timezone offset for the given timestamp.
-	expectedTimestamp := timestamp.In(loc)
+	// Get the timezone offset at the reference instant (the timestamp itself
+	// unless CheckTimezoneConsistencyAt supplied another).
+	expectedTimestamp := ref.In(loc)
 	_, originalOffset := timestamp.Zone()
 	_, expectedOffset := expectedTimestamp.Zone()
 
 	result.OriginalOffset = originalOffset
 	result.ExpectedOffset = expectedOffset
 
-	// Check if offsets match (allowing for some flexibility with DST transitions).
-	// Etc/GMT zones need no special casing: their POSIX-inverted sign only
+	// Check if offsets match. The expected offset is taken from the zone's
+	// rules at the same instant, so DST is accounted for: in an ambiguous
+	// fall-back hour each reading names a distinct instant, and either one
+	// matches. Etc/GMT zones need no special casing: their POSIX-inverted sign only
 	// affects the name, and Go resolves the actual offset correctly.
 	result.IsConsistent = (originalOffset == expectedOffset)
 
 	// In strict mode, return an error for inconsistencies
 	if strict && !result.IsConsistent {
-		return nil, ErrTimezoneOffsetMismatch
+		return nil, newOffsetMismatchError(location.String(), originalOffset, expectedOffset)
 	}
 
 	// In non-strict mode (callers escalate critical zones to strict per
@@ -99,13 +141,37 @@ func checkTimezoneConsistency(
 // parsing "[+09:00]") is authoritative as-is and has no timezone-database
 // entry, so the location is returned unchanged. Any other name — including a
 // placeholder FixedZone constructed by a caller — resolves through the
-// timezone-database cache; an unknown name returns the load error.
-func resolveLocation(location *time.Location) (*time.Location, error) {
+// timezone-database cache; an unknown name returns the load error. Backward
+// links such as "Asia/Calcutta" resolve to their target zone's rules, so
+// offsets are compared against the canonical zone while the location keeps
+// the name as written.
+//
+// A location loaded from zone data is returned as is, without a cache probe;
+// only placeholders (see isPlaceholderZone) go through the steps above.
+func resolveLocation(location *time.Location, loader LocationLoader) (*time.Location, error) {
+	if !isPlaceholderZone(location) {
+		return location, nil
+	}
 	name := location.String()
+	if loc, ok := cachedLocationFor(name, loader); ok {
+		return loc, nil
+	}
 	if isOffsetLocationName(name) {
 		return location, nil
 	}
-	return loadLocationCached(name)
+	return loadLocationCached(name, loader)
+}
+
+// isPlaceholderZone reports whether location may be a placeholder rather
+// than a zone loaded from zone data. time.FixedZone uses its name as the
+// zone abbreviation, so a FixedZone such as "Asia/Tokyo" or "+09:00" reports
+// its own name from Zone, while a loaded zone reports abbreviations such as
+// "JST". The few loaded zones whose abbreviation is their name, such as
+// "UTC", are treated as placeholders and resolve through the cache, which
+// gives the same result.
+func isPlaceholderZone(location *time.Location) bool {
+	abbrev, _ := time.Time{}.In(location).Zone()
+	return abbrev == location.String()
 }
 
 // resolveZoneAnnotation resolves the body of a time-zone annotation
@@ -115,8 +181,8 @@ func resolveLocation(location *time.Location) (*time.Location, error) {
 // round-trips the annotation per RFC 9557 Section 1.2 and the Section 4.1
 // time-numoffset grammar. An unknown or invalid name returns
 // ErrInvalidTimezone; whether that is fatal is the caller's decision.
-func resolveZoneAnnotation(name string) (*time.Location, error) {
-	if loc, ok := tryLoadTimezone(name); ok {
+func resolveZoneAnnotation(name string, loader LocationLoader) (*time.Location, error) {
+	if loc, ok := tryLoadTimezone(name, loader); ok {
 		return loc, nil
 	}
 	if offset, err := parseNumericOffset(name); err == nil {
@@ -125,33 +191,125 @@ func resolveZoneAnnotation(name string) (*time.Location, error) {
 	return nil, ErrInvalidTimezone
 }
 
+// OffsetAt returns the UTC offset, in seconds east of UTC, that the zone
+// named by a time-zone annotation body has at the instant t: an IANA name
+// such as "America/New_York", loaded through the package-level cache, or a
+// numeric offset such as "+09:00". This is the offset the consistency check
+// of RFC 9557 Section 3.4 compares against. A name that does not resolve
+// returns a *TimezoneError matching ErrInvalidTimezone.
+func OffsetAt(zoneName string, t time.Time) (int, error) {
+	loc, err := resolveZoneAnnotation(zoneName, stdLocationLoader{})
+	if err != nil {
+		return 0, newTimezoneError(zoneName)
+	}
+	_, offset := t.In(loc).Zone()
+	return offset, nil
+}
+
+// LocationLoader resolves an IANA time-zone name to a location. The default
+// loader wraps time.LoadLocation; see WithLocationLoader.
+type LocationLoader interface {
+	Load(name string) (*time.Location, error)
+}
+
+// stdLocationLoader is the default LocationLoader backed by time.LoadLocation.
+type stdLocationLoader struct{}
+
+func (stdLocationLoader) Load(name string) (*time.Location, error) {
+	return time.LoadLocation(name)
+}
+
 // timezoneCache stores successfully loaded *time.Location by name.
 //
 //nolint:gochecknoglobals // Package-level cache avoids repeated time.LoadLocation cost; safe read-mostly structure.
 var timezoneCache sync.Map // map[string]*time.Location
 
-// loadLocationCached loads a timezone using cache.
-func loadLocationCached(name string) (*time.Location, error) {
+// cachedLocation returns the location cached under name, if any.
+func cachedLocation(name string) (*time.Location, bool) {
 	if v, ok := timezoneCache.Load(name); ok {
-		if loc, ok2 := v.(*time.Location); ok2 {
-			return loc, nil
-		}
+		loc, ok := v.(*time.Location)
+		return loc, ok
+	}
+	return nil, false
+}
+
+// cachedLocationFor is cachedLocation for a lookup through loader. The cache
+// holds only zones from the default loader, so it never answers for a custom
+// one, which may resolve the same name differently.
+func cachedLocationFor(name string, loader LocationLoader) (*time.Location, bool) {
+	if _, ok := loader.(stdLocationLoader); !ok {
+		return nil, false
+	}
+	return cachedLocation(name)
+}
+
+// loadLocationCached loads a timezone using cache, falling back to loader on
+// a miss. A custom loader is called on every lookup and its results are not
+// cached. Only successful loads of names that are valid time-zone
+// annotations are cached, so a cache hit also vouches for the syntax; "",
+// which time.LoadLocation reads as UTC, loads but is not stored. Keys are
+// cloned so a cached name never pins the string it was parsed from.
+func loadLocationCached(name string, loader LocationLoader) (*time.Location, error) {
+	if loc, ok := cachedLocationFor(name, loader); ok {
+		return loc, nil
 	}
-	loc, err := time.LoadLocation(name)
+	loc, err := loader.Load(name)
 	if err != nil {
 		return nil, err
 	}
-	timezoneCache.Store(name, loc)
+	if loc == nil {
+		return nil, ErrInvalidTimezone
+	}
+	if _, ok := loader.(stdLocationLoader); ok && abnf.IsTimezoneSyntax(name) {
+		timezoneCache.Store(strings.Clone(name), loc)
+	}
 	return loc, nil
 }
 
+// PreloadTimezones loads the named IANA time zones into the package-level
+// cache, so the first Parse, Validate, or Format that references them does
+// not pay the time.LoadLocation cost. Every name is attempted; the returned
+// error joins the failures, if any.
+func PreloadTimezones(names ...string) error {
+	var errs []error
+	for _, name := range names {
+		if _, err := loadLocationCached(name, stdLocationLoader{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ClearTimezoneCache removes every location from the package-level cache.
+// Subsequent lookups reload zones on demand.
+func ClearTimezoneCache() {
+	timezoneCache.Clear()
+}
+
+// TimezoneCacheLen returns the number of locations in the package-level
+// cache, for example to report as a metric.
+func TimezoneCacheLen() int {
+	n := 0
+	timezoneCache.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
 // tryLoadTimezone attempts to treat s as a timezone name (not numeric offset) and load it.
 // Returns (location, true) if loaded, (nil, false) otherwise. Errors are treated as non-match.
-func tryLoadTimezone(s string) (*time.Location, bool) {
+//
+// A cached name is returned without repeating the syntax check, since
+// loadLocationCached only caches valid names.
+func tryLoadTimezone(s string, loader LocationLoader) (*time.Location, bool) {
+	if loc, ok := cachedLocationFor(s, loader); ok {
+		return loc, true
+	}
 	if s == "" || !abnf.IsTimezoneSyntax(s) {
 		return nil, false
 	}
-	loc, err := loadLocationCached(s)
+	loc, err := loadLocationCached(s, loader)
 	if err != nil {
 		return nil, false
 	}
@@ -186,17 +344,69 @@ func parseNumericOffset(s string) (int, error) {
 
 // isOffsetLocationName reports whether name is a numeric-offset zone name in
 // the RFC 3339 serialization form used for offset time-zone annotations
-// (e.g. "+09:00", "-03:30"), as produced when parsing "[+09:00]". This is the
-// single definition of the offset-name shape; parseNumericOffset builds on it.
+// (e.g. "+09:00", "-03:30"), as produced when parsing "[+09:00]". The shape is
+// defined by abnf.AbnfOffset; parseNumericOffset builds on it.
 func isOffsetLocationName(name string) bool {
-	const offsetNameLength = 6 // len("+09:00")
-	if len(name) != offsetNameLength || (name[0] != '+' && name[0] != '-') || name[3] != ':' {
-		return false
+	return abnf.AbnfOffset.ValidateOffset(name) == nil
+}
+
+// systemLocation returns the IANA zone behind time.Local for use as an
+// annotation, or nil when it cannot be determined or does not match t's
+// offset. Go names the system zone "Local", which is not a valid annotation.
+func systemLocation(t time.Time) *time.Location {
+	name := systemZoneName()
+	if name == "" {
+		return nil
+	}
+	loc, err := loadLocationCached(name, stdLocationLoader{})
+	if err != nil {
+		return nil
+	}
+	_, want := t.Zone()
+	if _, got := t.In(loc).Zone(); got != want {
+		return nil
 	}
-	for _, i := range [...]int{1, 2, 4, 5} {
-		if name[i] < '0' || name[i] > '9' {
-			return false
+	return loc
+}
+
+// systemZoneName is localZoneName for the running process, computed once
+// like time.Local itself.
+//
+//nolint:gochecknoglobals // Cached once per process, mirroring time.Local initialization.
+var systemZoneName = sync.OnceValue(func() string {
+	return localZoneName(os.LookupEnv, os.Readlink)
+})
+
+// localZoneName derives the IANA name of the system zone the way the time
+// package picks it: the TZ environment variable ("" meaning UTC, a leading
+// ':' ignored, a path into a zoneinfo directory reduced to its zone name),
+// then the /etc/localtime symlink target. It returns "" when neither names a
+// zone.
+func localZoneName(lookupEnv func(string) (string, bool), readlink func(string) (string, error)) string {
+	if tz, ok := lookupEnv("TZ"); ok {
+		tz = strings.TrimPrefix(tz, ":")
+		switch {
+		case tz == "":
+			return "UTC"
+		case strings.HasPrefix(tz, "/"):
+			return zoneinfoName(tz)
+		default:
+			return tz
 		}
 	}
-	return true
+	target, err := readlink("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	return zoneinfoName(target)
+}
+
+// zoneinfoName returns the part of a zoneinfo file path after the
+// "zoneinfo/" directory, or "" when the path has none.
+func zoneinfoName(path string) string {
+	const dir = "zoneinfo/"
+	if i := strings.LastIndex(path, dir); i >= 0 {
+		return path[i+len(dir):]
+	}
+	return ""
 }
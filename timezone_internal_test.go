@@ -1,6 +1,8 @@
 package ixdtf
 
 import (
+	"errors"
+	"sort"
 	"testing"
 	"time"
 )
@@ -13,7 +15,7 @@ func TestCheckTimezoneConsistency(t *testing.T) {
 		t.Parallel()
 		timezoneCache.Delete("Asia/Tokyo")
 		placeholder := time.FixedZone("Asia/Tokyo", 9*3600)
-		res, err := checkTimezoneConsistency(now, placeholder, false, false)
+		res, err := checkTimezoneConsistency(now, placeholder, false, false, stdLocationLoader{})
 		if err != nil {
 			t.Fatalf("expected fallback load to succeed, got %v", err)
 		}
@@ -24,14 +26,16 @@ func TestCheckTimezoneConsistency(t *testing.T) {
 
 	t.Run("nil location", func(t *testing.T) {
 		t.Parallel()
-		if res, err := checkTimezoneConsistency(now, nil, false, false); err != nil || !res.IsConsistent {
+		res, err := checkTimezoneConsistency(now, nil, false, false, stdLocationLoader{})
+		if err != nil || !res.IsConsistent {
 			t.Fatalf("expected nil location to be consistent, got res=%+v err=%v", res, err)
 		}
 	})
 
 	t.Run("strict mode with unknown fixed zone", func(t *testing.T) {
 		t.Parallel()
-		if _, err := checkTimezoneConsistency(now, time.FixedZone("+0900", 9*3600), true, false); err == nil {
+		fixed := time.FixedZone("+0900", 9*3600)
+		if _, err := checkTimezoneConsistency(now, fixed, true, false, stdLocationLoader{}); err == nil {
 			t.Fatalf("expected strict mode to fail for unknown fixed zone")
 		}
 	})
@@ -45,12 +49,12 @@ func TestCheckTimezoneConsistency(t *testing.T) {
 		// July: London is BST (+01:00), which differs from the Z offset (0),
 		// yet an unknown local offset must never be flagged as inconsistent.
 		summer := time.Date(2022, 7, 8, 0, 14, 7, 0, time.UTC)
-		res, err := checkTimezoneConsistency(summer, london, true, true)
+		res, err := checkTimezoneConsistency(summer, london, true, true, stdLocationLoader{})
 		if err != nil {
 			t.Fatalf("unknown offset should not error in strict mode, got %v", err)
 		}
-		if !res.IsConsistent {
-			t.Fatalf("unknown offset should be consistent, got %+v", res)
+		if !res.IsConsistent || !res.Skipped {
+			t.Fatalf("unknown offset should be consistent and skipped, got %+v", res)
 		}
 	})
 }
@@ -115,7 +119,7 @@ func TestCheckTimezoneConsistencyUnloadableZone(t *testing.T) {
 
 	t.Run("non-strict treats unloadable zone as consistent", func(t *testing.T) {
 		t.Parallel()
-		result, err := checkTimezoneConsistency(ts, unknown, false, false)
+		result, err := checkTimezoneConsistency(ts, unknown, false, false, stdLocationLoader{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -126,7 +130,7 @@ func TestCheckTimezoneConsistencyUnloadableZone(t *testing.T) {
 
 	t.Run("strict errors on unloadable zone", func(t *testing.T) {
 		t.Parallel()
-		if _, err := checkTimezoneConsistency(ts, unknown, true, false); err == nil {
+		if _, err := checkTimezoneConsistency(ts, unknown, true, false, stdLocationLoader{}); err == nil {
 			t.Fatalf("expected error for unloadable zone in strict mode")
 		}
 	})
@@ -137,7 +141,7 @@ func TestResolveLocationOffsetZone(t *testing.T) {
 	// A numeric-offset zone name is authoritative as-is and must not be
 	// resolved through the timezone database.
 	offset := time.FixedZone("+09:00", 9*3600)
-	got, err := resolveLocation(offset)
+	got, err := resolveLocation(offset, stdLocationLoader{})
 	if err != nil {
 		t.Fatalf("resolveLocation(+09:00) returned error: %v", err)
 	}
@@ -145,3 +149,133 @@ func TestResolveLocationOffsetZone(t *testing.T) {
 		t.Fatalf("resolveLocation(+09:00) = %v, want the offset zone unchanged", got)
 	}
 }
+
+func TestResolveLocationLoadedZone(t *testing.T) {
+	t.Parallel()
+	if _, err := loadLocationCached("Asia/Tokyo", stdLocationLoader{}); err != nil {
+		t.Skipf("Asia/Tokyo unavailable: %v", err)
+	}
+	// A loaded zone is returned as is rather than swapped for the cached
+	// location of the same name.
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Asia/Tokyo unavailable: %v", err)
+	}
+	if got, err := resolveLocation(tokyo, stdLocationLoader{}); err != nil || got != tokyo {
+		t.Errorf("resolveLocation(loaded Asia/Tokyo) = %p, %v, want %p", got, err, tokyo)
+	}
+
+	for _, loc := range []*time.Location{time.FixedZone("Asia/Tokyo", 9*3600), time.FixedZone("+09:00", 9*3600)} {
+		if !isPlaceholderZone(loc) {
+			t.Errorf("isPlaceholderZone(FixedZone(%q)) = false, want true", loc)
+		}
+	}
+	if isPlaceholderZone(tokyo) {
+		t.Error("isPlaceholderZone(loaded Asia/Tokyo) = true, want false")
+	}
+}
+
+func TestLoadLocationCachedSkipsInvalidNames(t *testing.T) {
+	t.Parallel()
+	// time.LoadLocation reads "" as UTC, but "" is not an annotation name;
+	// caching it would let tryLoadTimezone accept it without the syntax check.
+	if _, err := loadLocationCached("", stdLocationLoader{}); err != nil {
+		t.Fatalf("loadLocationCached(\"\") returned error: %v", err)
+	}
+	if _, ok := cachedLocation(""); ok {
+		t.Errorf("cachedLocation(\"\") found an entry, want none")
+	}
+	if loc, ok := tryLoadTimezone("", stdLocationLoader{}); ok {
+		t.Errorf("tryLoadTimezone(\"\") = %v, want no match", loc)
+	}
+}
+
+// TestCheckTimezoneConsistencyDSTTransitions verifies the offset comparison
+// around America/New_York DST transitions: on either side of spring-forward,
+// for both readings of the ambiguous fall-back hour, and for a winter offset
+// used in summer.
+func TestCheckTimezoneConsistencyDSTTransitions(t *testing.T) {
+	t.Parallel()
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("America/New_York unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		input          string
+		wantConsistent bool
+	}{
+		{"summer with DST offset", "2025-07-15T12:00:00-04:00", true},
+		{"summer with winter offset", "2025-07-15T12:00:00-05:00", false},
+		{"last second before spring-forward", "2025-03-09T01:59:59-05:00", true},
+		{"first second after spring-forward", "2025-03-09T03:00:00-04:00", true},
+		{"spring-forward with stale winter offset", "2025-03-09T03:00:00-05:00", false},
+		{"ambiguous fall-back hour, first occurrence", "2025-11-02T01:30:00-04:00", true},
+		{"ambiguous fall-back hour, second occurrence", "2025-11-02T01:30:00-05:00", true},
+		{"after fall-back with stale summer offset", "2025-11-02T02:30:00-04:00", false},
+	}
+
+	sort.Slice(tests, func(i, j int) bool { return tests[i].name < tests[j].name })
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ts, err := time.Parse(time.RFC3339, tc.input)
+			if err != nil {
+				t.Fatalf("time.Parse(%q) unexpected error: %v", tc.input, err)
+			}
+
+			res, err := checkTimezoneConsistency(ts, newYork, false, false, stdLocationLoader{})
+			if err != nil {
+				t.Fatalf("non-strict check for %q unexpected error: %v", tc.input, err)
+			}
+			if res.IsConsistent != tc.wantConsistent {
+				t.Errorf("non-strict check for %q IsConsistent = %v, want %v (original %d, expected %d)",
+					tc.input, res.IsConsistent, tc.wantConsistent, res.OriginalOffset, res.ExpectedOffset)
+			}
+
+			_, err = checkTimezoneConsistency(ts, newYork, true, false, stdLocationLoader{})
+			if tc.wantConsistent && err != nil {
+				t.Errorf("strict check for %q unexpected error: %v", tc.input, err)
+			}
+			if !tc.wantConsistent && !errors.Is(err, ErrTimezoneOffsetMismatch) {
+				t.Errorf("strict check for %q error = %v, want ErrTimezoneOffsetMismatch", tc.input, err)
+			}
+		})
+	}
+}
+
+func TestLocalZoneName(t *testing.T) {
+	t.Parallel()
+
+	noLink := func(string) (string, error) { return "", errors.New("no symlink") }
+	link := func(string) (string, error) { return "/usr/share/zoneinfo/Europe/Berlin", nil }
+	env := func(value string, set bool) func(string) (string, bool) {
+		return func(string) (string, bool) { return value, set }
+	}
+
+	tests := []struct {
+		name      string
+		lookupEnv func(string) (string, bool)
+		readlink  func(string) (string, error)
+		want      string
+	}{
+		{"TZ name", env("Asia/Tokyo", true), link, "Asia/Tokyo"},
+		{"TZ with colon", env(":America/New_York", true), link, "America/New_York"},
+		{"empty TZ is UTC", env("", true), link, "UTC"},
+		{"TZ zoneinfo path", env("/usr/share/zoneinfo/Asia/Tokyo", true), link, "Asia/Tokyo"},
+		{"TZ other path", env("/etc/mytz", true), link, ""},
+		{"localtime symlink", env("", false), link, "Europe/Berlin"},
+		{"nothing available", env("", false), noLink, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := localZoneName(tt.lookupEnv, tt.readlink); got != tt.want {
+				t.Errorf("localZoneName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package ixdtf_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+// TestTimezoneCacheControl exercises the cache control APIs. It does not call
+// t.Parallel because clearing the package-level cache would race with the
+// length assertions of concurrently running tests.
+func TestTimezoneCacheControl(t *testing.T) {
+	ixdtf.ClearTimezoneCache()
+	if got := ixdtf.TimezoneCacheLen(); got != 0 {
+		t.Fatalf("TimezoneCacheLen() after clear = %d, want 0", got)
+	}
+
+	if err := ixdtf.PreloadTimezones("Asia/Tokyo", "Europe/Paris"); err != nil {
+		t.Fatalf("PreloadTimezones unexpected error: %v", err)
+	}
+	if got := ixdtf.TimezoneCacheLen(); got != 2 {
+		t.Fatalf("TimezoneCacheLen() after preload = %d, want 2", got)
+	}
+
+	if err := ixdtf.PreloadTimezones("America/New_York", "No/SuchZone"); err == nil {
+		t.Fatalf("PreloadTimezones with an unknown zone expected error, got nil")
+	}
+	if got := ixdtf.TimezoneCacheLen(); got != 3 {
+		t.Fatalf("TimezoneCacheLen() after partial preload = %d, want 3", got)
+	}
+
+	ixdtf.ClearTimezoneCache()
+	if got := ixdtf.TimezoneCacheLen(); got != 0 {
+		t.Fatalf("TimezoneCacheLen() after second clear = %d, want 0", got)
+	}
+	_, first, err := ixdtf.Parse("2025-01-01T00:00:00+09:00[Asia/Tokyo]", true)
+	if err != nil {
+		t.Fatalf("Parse after clear unexpected error: %v", err)
+	}
+
+	// Repeated annotations share one interned location.
+	_, second, err := ixdtf.Parse("2025-06-01T00:00:00Z[Asia/Tokyo][u-ca=gregory]", false)
+	if err != nil {
+		t.Fatalf("Parse unexpected error: %v", err)
+	}
+	if first.Location != second.Location {
+		t.Errorf("Location pointers differ: %p, %p", first.Location, second.Location)
+	}
+}
+
+func TestTimezoneErrorSuggestion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		name  string
+		want  string
+	}{
+		{"2025-01-01T00:00:00+09:00[Asia/Tokio]", "Asia/Tokio", "Asia/Tokyo"},
+		{"2025-01-01T00:00:00-05:00[Americ/New_York]", "Americ/New_York", "America/New_York"},
+		{"2025-01-01T00:00:00Z[No/SuchZoneAtAll]", "No/SuchZoneAtAll", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := ixdtf.Parse(tt.input, true)
+			if !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+				t.Fatalf("Parse(%q) error = %v, want ErrInvalidTimezone", tt.input, err)
+			}
+			var tzErr *ixdtf.TimezoneError
+			if !errors.As(err, &tzErr) {
+				t.Fatalf("Parse(%q) error = %v, want *TimezoneError", tt.input, err)
+			}
+			if tzErr.Name != tt.name {
+				t.Errorf("TimezoneError.Name = %q, want %q", tzErr.Name, tt.name)
+			}
+			if tt.want != "" && tzErr.Suggestion == "" {
+				t.Skip("system zoneinfo directory unavailable; no suggestions")
+			}
+			if tzErr.Suggestion != tt.want {
+				t.Errorf("TimezoneError.Suggestion = %q, want %q", tzErr.Suggestion, tt.want)
+			}
+			if tt.want != "" && !strings.Contains(err.Error(), `(did you mean "`+tt.want+`"?)`) {
+				t.Errorf("Parse(%q) error = %q, want a did-you-mean hint", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestTimezoneErrorOffsetMismatch(t *testing.T) {
+	t.Parallel()
+
+	for _, validate := range []bool{false, true} {
+		var err error
+		if validate {
+			err = ixdtf.Validate("2025-06-01T12:00:00+09:00[America/New_York]", true)
+		} else {
+			_, _, err = ixdtf.Parse("2025-06-01T12:00:00+09:00[America/New_York]", true)
+		}
+		if !errors.Is(err, ixdtf.ErrTimezoneOffsetMismatch) || errors.Is(err, ixdtf.ErrInvalidTimezone) {
+			t.Fatalf("error = %v, want only ErrTimezoneOffsetMismatch", err)
+		}
+		var tzErr *ixdtf.TimezoneError
+		if !errors.As(err, &tzErr) {
+			t.Fatalf("error = %v, want *TimezoneError", err)
+		}
+		if tzErr.Kind != ixdtf.TimezoneErrorOffsetMismatch || tzErr.Name != "America/New_York" {
+			t.Errorf("TimezoneError = %+v, want an offset mismatch for America/New_York", tzErr)
+		}
+		if tzErr.OriginalOffset != 9*3600 || tzErr.ExpectedOffset != -4*3600 {
+			t.Errorf("offsets = %d, %d, want %d, %d", tzErr.OriginalOffset, tzErr.ExpectedOffset, 9*3600, -4*3600)
+		}
+		want := `offset +09:00 does not match "America/New_York" (expected -04:00)`
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to contain %q", err, want)
+		}
+	}
+
+	_, _, err := ixdtf.Parse("2025-01-01T00:00:00Z[Mars/Olympus]", true)
+	var tzErr *ixdtf.TimezoneError
+	if !errors.As(err, &tzErr) || tzErr.Kind != ixdtf.TimezoneErrorInvalid {
+		t.Errorf("Parse error = %v, want a TimezoneErrorInvalid", err)
+	}
+}
+
+func TestCheckTimezoneConsistencyAt(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("zoneinfo unavailable: %v", err)
+	}
+	// A winter wall time written with the summer offset.
+	ts := time.Date(2025, 1, 15, 12, 0, 0, 0, time.FixedZone("", -4*3600))
+	summer := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		ref            time.Time
+		wantConsistent bool
+		wantExpected   int
+	}{
+		{"ref equal to t", ts, false, -5 * 3600},
+		{"summer ref", summer, true, -4 * 3600},
+	}
+	for _, tt := range tests {
+		res, err := ixdtf.CheckTimezoneConsistencyAt(ts, newYork, tt.ref)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if res.IsConsistent != tt.wantConsistent || res.ExpectedOffset != tt.wantExpected ||
+			res.OriginalOffset != -4*3600 {
+			t.Errorf("%s: result = %+v, want consistent %t, expected offset %d",
+				tt.name, res, tt.wantConsistent, tt.wantExpected)
+		}
+	}
+
+	if res, err := ixdtf.CheckTimezoneConsistencyAt(ts, nil, summer); err != nil || !res.IsConsistent {
+		t.Errorf("nil location = %+v, %v, want consistent", res, err)
+	}
+	placeholder := time.FixedZone("Mars/Olympus", 0)
+	if _, err := ixdtf.CheckTimezoneConsistencyAt(ts, placeholder, summer); !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+		t.Errorf("unknown zone error = %v, want ErrInvalidTimezone", err)
+	}
+}
+
+func TestTimezoneConsistencyResultTimezone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-01-02T12:04:05+09:00[Asia/Tokyo]", "Asia/Tokyo"},
+		{"2025-01-02T03:04:05Z[!Asia/Tokyo]", "Asia/Tokyo"},
+		{"2025-01-02T08:34:05+05:30[Asia/Calcutta]", "Asia/Calcutta"},
+		{"2025-01-02T12:04:05+09:00[+09:00]", "+09:00"},
+		{"2025-01-02T12:04:05-03:30[-03:30]", "-03:30"},
+	}
+	for _, tt := range tests {
+		for _, opts := range [][]ixdtf.ParseOption{nil, {ixdtf.WithStrict()}} {
+			r, err := ixdtf.ParseResultOf(tt.input, opts...)
+			if err != nil {
+				t.Fatalf("ParseResultOf(%q) unexpected error: %v", tt.input, err)
+			}
+			if r.Consistency == nil || r.Consistency.Timezone != tt.want {
+				t.Errorf("ParseResultOf(%q) Consistency = %+v, want Timezone %q", tt.input, r.Consistency, tt.want)
+			}
+		}
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("zoneinfo unavailable: %v", err)
+	}
+	now := time.Now()
+	if res, err := ixdtf.CheckTimezoneConsistencyAt(now, tokyo, now); err != nil || res.Timezone != "Asia/Tokyo" {
+		t.Errorf("CheckTimezoneConsistencyAt() = %+v, %v, want Timezone %q", res, err, "Asia/Tokyo")
+	}
+	if res, err := ixdtf.CheckTimezoneConsistencyAt(now, nil, now); err != nil || res.Timezone != "" {
+		t.Errorf("CheckTimezoneConsistencyAt(nil) = %+v, %v, want empty Timezone", res, err)
+	}
+}
+
+func TestTimezoneConsistencySkippedForUnknownOffset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input       string
+		wantSkipped bool
+	}{
+		{"2025-06-01T12:00:00-00:00[America/New_York]", true},
+		{"2025-06-01T12:00:00Z[!America/New_York]", true},
+		{"2025-06-01T08:00:00-04:00[America/New_York]", false},
+	}
+	for _, tt := range tests {
+		r, err := ixdtf.ParseResultOf(tt.input, ixdtf.WithStrict())
+		if err != nil {
+			t.Fatalf("ParseResultOf(%q, WithStrict()) unexpected error: %v", tt.input, err)
+		}
+		c := r.Consistency
+		if c == nil || !c.IsConsistent || c.Skipped != tt.wantSkipped || c.ExpectedOffset != -4*3600 {
+			t.Errorf("ParseResultOf(%q) Consistency = %+v, want consistent, Skipped %t", tt.input, c, tt.wantSkipped)
+		}
+	}
+
+	// A concrete "+00:00" is compared and fails.
+	if _, _, err := ixdtf.Parse("2025-06-01T12:00:00+00:00[America/New_York]", true); err == nil {
+		t.Error("Parse with +00:00 expected an offset mismatch, got nil")
+	}
+}
+
+func TestOffsetAt(t *testing.T) {
+	t.Parallel()
+
+	winter := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		zone string
+		at   time.Time
+		want int
+	}{
+		{"America/New_York", winter, -5 * 3600},
+		{"America/New_York", summer, -4 * 3600},
+		{"Asia/Tokyo", winter, 9 * 3600},
+		{"Asia/Tokyo", summer, 9 * 3600},
+		{"+05:30", summer, 5*3600 + 30*60},
+		{"UTC", summer, 0},
+	}
+	for _, tt := range tests {
+		got, err := ixdtf.OffsetAt(tt.zone, tt.at)
+		if err != nil {
+			t.Fatalf("OffsetAt(%q, %v) unexpected error: %v", tt.zone, tt.at, err)
+		}
+		if got != tt.want {
+			t.Errorf("OffsetAt(%q, %v) = %d, want %d", tt.zone, tt.at, got, tt.want)
+		}
+	}
+
+	for _, zone := range []string{"Mars/Olympus", "", "+24:00"} {
+		if _, err := ixdtf.OffsetAt(zone, summer); !errors.Is(err, ixdtf.ErrInvalidTimezone) {
+			t.Errorf("OffsetAt(%q) error = %v, want %v", zone, err, ixdtf.ErrInvalidTimezone)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package ixdtf
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseTrace records, in order, the decisions taken while parsing an IXDTF
+// string: where the input was split, how the time-zone annotation was
+// resolved, which relaxations applied, and the outcome of the consistency
+// check. It is meant for troubleshooting; the step wording is not a stable
+// API.
+type ParseTrace struct {
+	Steps []string
+}
+
+// WithParseTrace records the decisions taken by Parse or Validate into trace.
+// A nil trace disables recording, which is also the default; tracing adds no
+// work to a parse unless it is enabled.
+func WithParseTrace(trace *ParseTrace) ParseOption {
+	return func(o *parseOptions) {
+		o.trace = trace
+	}
+}
+
+// ParseDiagnostic is the diagnostic variant of Parse: it parses s like Parse
+// and also returns the recorded ParseTrace. The trace is returned even when
+// parsing fails, so it shows how far the parse got.
+func ParseDiagnostic(
+	s string,
+	strict bool,
+	opts ...ParseOption,
+) (time.Time, *IXDTFExtensions, *ParseTrace, error) {
+	trace := &ParseTrace{}
+	// Cap opts so appending never writes into the caller's backing array.
+	opts = append(opts[:len(opts):len(opts)], WithParseTrace(trace))
+	t, ext, err := Parse(s, strict, opts...)
+	return t, ext, trace, err
+}
+
+func (tr *ParseTrace) addf(format string, args ...any) {
+	tr.Steps = append(tr.Steps, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,71 @@
+package ixdtf_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/8beeeaaat/ixdtf"
+)
+
+func TestParseDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("offset mismatch in non-strict mode", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-06-01T12:00:00+09:00[America/New_York][u-ca=gregory]"
+		_, _, trace, err := ixdtf.ParseDiagnostic(input, false)
+		if err != nil {
+			t.Fatalf("ParseDiagnostic(%q, false) unexpected error: %v", input, err)
+		}
+		want := []string{
+			"split at offset 25",
+			"tag u-ca=gregory (critical=false)",
+			"offset inconsistent (original 32400, expected -14400 seconds), original offset kept",
+		}
+		for _, step := range want {
+			if !slices.Contains(trace.Steps, step) {
+				t.Errorf("ParseDiagnostic(%q) steps = %q, missing %q", input, trace.Steps, step)
+			}
+		}
+		if !containsPrefix(trace.Steps, "zone America/New_York ") {
+			t.Errorf("ParseDiagnostic(%q) steps = %q, missing zone resolution", input, trace.Steps)
+		}
+	})
+
+	t.Run("offset mismatch in strict mode records the failure", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-06-01T12:00:00+09:00[America/New_York]"
+		_, _, trace, err := ixdtf.ParseDiagnostic(input, true)
+		if err == nil {
+			t.Fatalf("ParseDiagnostic(%q, true) expected error, got nil", input)
+		}
+		if !containsPrefix(trace.Steps, "consistency check failed (strict=true)") {
+			t.Errorf("ParseDiagnostic(%q, true) steps = %q, missing failed check", input, trace.Steps)
+		}
+	})
+
+	t.Run("ignored unknown zone", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00Z[Foo/Bar]"
+		_, _, trace, err := ixdtf.ParseDiagnostic(input, false)
+		if err != nil {
+			t.Fatalf("ParseDiagnostic(%q, false) unexpected error: %v", input, err)
+		}
+		if !slices.Contains(trace.Steps, "zone Foo/Bar unknown, ignored in non-strict mode") {
+			t.Errorf("ParseDiagnostic(%q) steps = %q, missing ignored zone", input, trace.Steps)
+		}
+	})
+
+	t.Run("nil trace records nothing", func(t *testing.T) {
+		t.Parallel()
+		const input = "2025-01-01T00:00:00+09:00[Asia/Tokyo]"
+		if _, _, err := ixdtf.Parse(input, false, ixdtf.WithParseTrace(nil)); err != nil {
+			t.Fatalf("Parse(%q, false) unexpected error: %v", input, err)
+		}
+	})
+}
+
+func containsPrefix(steps []string, prefix string) bool {
+	return slices.ContainsFunc(steps, func(step string) bool { return strings.HasPrefix(step, prefix) })
+}
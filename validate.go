@@ -1,6 +1,7 @@
 package ixdtf
 
 import (
+	"errors"
 	"time"
 
 	"github.com/8beeeaaat/ixdtf/abnf"
@@ -8,13 +9,14 @@ import (
 
 // validateExtensionsStrict validates IXDTF extensions for correctness and
 // processes critical extensions (RFC 9557 Section 3.3). In strict mode,
-// registered tag values are also validated.
-func validateExtensionsStrict(ext *IXDTFExtensions, strict bool) error {
+// registered tag values are also validated. Named locations are resolved
+// through loader.
+func validateExtensionsStrict(ext *IXDTFExtensions, strict bool, loader LocationLoader) error {
 	if ext == nil {
 		return nil
 	}
 
-	if err := validateLocationStrict(ext.Location, strict); err != nil {
+	if err := validateLocationStrict(ext.Location, strict, loader); err != nil {
 		return err
 	}
 
@@ -35,45 +37,94 @@ func validateExtensionsStrict(ext *IXDTFExtensions, strict bool) error {
 	return nil
 }
 
-func validateLocationStrict(location *time.Location, strict bool) error {
+func validateLocationStrict(location *time.Location, strict bool, loader LocationLoader) error {
 	if location == nil {
 		return nil
 	}
 	// An offset-derived FixedZone (e.g. from "[+09:00]") resolves to itself;
 	// unknown named zones are ignored in non-strict mode per RFC 9557.
-	if _, err := resolveLocation(location); err != nil && strict {
-		return ErrInvalidTimezone
+	if _, err := resolveLocation(location, loader); err != nil && strict {
+		return newTimezoneError(location.String())
 	}
 	return nil
 }
 
+// validateTag checks a tag supplied through the API rather than parsed:
+// the key against the suffix-key grammar and the value, which must be
+// non-empty, against the suffix-values grammar (RFC 9557 Section 4.1). An
+// invalid value is reported as ErrInvalidExtension.
+func validateTag(key, value string) error {
+	if hasControlByte(key) || hasControlByte(value) {
+		return ErrInvalidExtension
+	}
+	if err := abnf.AbnfSuffixKey.ValidateSuffixKey(key); err != nil {
+		return err
+	}
+	if value == "" || isValidSuffixValue(value) != nil {
+		return ErrInvalidExtension
+	}
+	return nil
+}
+
+// ValidateExtensions checks ext the way Format and Builder.Build do: the
+// time-zone annotation must resolve, every tag key must match the suffix-key
+// grammar and must not be a private or experimental key, every critical key
+// must have a processable tag, and registered values such as u-ca must be
+// known. A failing tag is reported as a *TagError naming the key, the first
+// in sorted order when several fail, which unwraps to the rule it broke
+// (e.g. ErrPrivateExtension). A nil ext is valid.
+func ValidateExtensions(ext *IXDTFExtensions) error {
+	return validateExtensionsStrict(ext, true, stdLocationLoader{})
+}
+
 func validateTagKeys(tags map[string]string) error {
 	// Basic tag key validation (syntactic). Value validation is already handled when creating tags.
-	for key := range tags {
-		if err := abnf.AbnfSuffixKey.ValidateSuffixKey(key); err != nil {
-			return err
+	// A key outside the grammar is reported as ErrInvalidExtension, which
+	// callers can match, rather than the abnf package's unexported error.
+	return firstTagError(tags, func(key, _ string) error {
+		err := abnf.AbnfSuffixKey.ValidateSuffixKey(key)
+		if err != nil && !errors.Is(err, ErrPrivateExtension) && !errors.Is(err, ErrExperimentalExtension) {
+			return ErrInvalidExtension
+		}
+		return err
+	})
+}
+
+// firstTagError runs check on every tag and returns a *TagError for the
+// smallest failing key, so the report does not depend on map order.
+func firstTagError(tags map[string]string, check func(key, value string) error) error {
+	var first *TagError
+	for key, value := range tags {
+		if err := check(key, value); err != nil && (first == nil || key < first.Key) {
+			first = &TagError{Key: key, Err: err}
 		}
 	}
-	return nil
+	if first == nil {
+		return nil
+	}
+	return first
 }
 
 func validateCriticalTags(tags map[string]string, critical map[string]bool) error {
 	// Critical tag processing:
 	// * If a key is marked critical but missing in Tags -> error.
 	// * If present but value is empty -> error.
+	var first *TagError
 	for key, isCritical := range critical {
-		if !isCritical {
+		if !isCritical || (first != nil && key > first.Key) {
 			continue
 		}
 		value, exists := tags[key]
 		if !exists { // missing critical tag
-			return ErrCriticalExtension
-		}
-		if err := validateCriticalExtension(key, value); err != nil {
-			return err
+			first = &TagError{Key: key, Err: ErrCriticalExtension}
+		} else if err := validateCriticalExtension(key, value); err != nil {
+			first = &TagError{Key: key, Err: err}
 		}
 	}
-	return nil
+	if first == nil {
+		return nil
+	}
+	return first
 }
 
 // validateCriticalExtension enforces critical extension processing rules.
@@ -85,10 +136,5 @@ func validateCriticalExtension(key, value string) error {
 }
 
 func validateTagValuesStrict(tags map[string]string) error {
-	for key, value := range tags {
-		if err := validateTagValue(key, value); err != nil {
-			return err
-		}
-	}
-	return nil
+	return firstTagError(tags, validateTagValue)
 }
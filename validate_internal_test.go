@@ -10,7 +10,7 @@ func TestValidateInternal(t *testing.T) {
 	t.Parallel()
 	t.Run("nil extensions", func(t *testing.T) {
 		t.Parallel()
-		if err := validateExtensionsStrict(nil, false); err != nil {
+		if err := validateExtensionsStrict(nil, false, stdLocationLoader{}); err != nil {
 			t.Fatalf("expected nil extensions to validate, got %v", err)
 		}
 	})
@@ -24,14 +24,15 @@ func TestValidateInternal(t *testing.T) {
 
 	t.Run("non-strict location validation", func(t *testing.T) {
 		t.Parallel()
-		if err := validateLocationStrict(time.FixedZone("No/SuchZone", 0), false); err != nil {
+		if err := validateLocationStrict(time.FixedZone("No/SuchZone", 0), false, stdLocationLoader{}); err != nil {
 			t.Fatalf("expected non-strict validation to ignore unknown zone, got %v", err)
 		}
 	})
 
 	t.Run("strict location validation", func(t *testing.T) {
 		t.Parallel()
-		if err := validateLocationStrict(time.FixedZone("No/SuchZone", 0), true); !errors.Is(err, ErrInvalidTimezone) {
+		err := validateLocationStrict(time.FixedZone("No/SuchZone", 0), true, stdLocationLoader{})
+		if !errors.Is(err, ErrInvalidTimezone) {
 			t.Fatalf("expected strict validation to fail, got %v", err)
 		}
 	})
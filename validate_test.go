@@ -1,8 +1,11 @@
 package ixdtf_test
 
 import (
+	"errors"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/8beeeaaat/ixdtf"
 )
@@ -66,7 +69,7 @@ func TestValidate(t *testing.T) {
 			name:    "invalid calendar tag (non-critical, strict)",
 			input:   "2025-03-04T05:06:07Z[u-ca=hoge]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-03-04T05:06:07Z[u-ca=hoge]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid calendar tag identifier",
+			wantErr: "IXDTFE parsing time \"2025-03-04T05:06:07Z[u-ca=hoge]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": tag key \"u-ca\": invalid calendar tag identifier",
 		},
 		{
 			name:    "invalid calendar tag (critical)",
@@ -88,7 +91,7 @@ func TestValidate(t *testing.T) {
 			name:    "invalid timezone name in strict mode",
 			input:   "2025-01-01T00:00:00Z[No/SuchZone]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[No/SuchZone]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name",
+			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[No/SuchZone]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name \"No/SuchZone\"",
 		},
 		{
 			name:   "invalid extension format in non-strict mode",
@@ -99,7 +102,7 @@ func TestValidate(t *testing.T) {
 			name:    "invalid extension format in strict mode",
 			input:   "2025-01-01T00:00:00Z[invalid]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[invalid]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name",
+			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[invalid]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name \"invalid\"",
 		},
 		{
 			name:    "private extension",
@@ -140,7 +143,7 @@ func TestValidate(t *testing.T) {
 			name:    "timezone offset mismatch in strict mode",
 			input:   "2025-06-01T12:00:00+09:00[America/New_York]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-06-01T12:00:00+09:00[America/New_York]\" as \"2006-01-02T15:04:05.999999999Z07:00*([time-zone-name][tags])\": timezone offset does not match the specified timezone",
+			wantErr: "IXDTFE parsing time \"2025-06-01T12:00:00+09:00[America/New_York]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": timezone offset does not match the specified timezone: offset +09:00 does not match \"America/New_York\" (expected -04:00)",
 		},
 		{
 			name:   "timezone content with u- prefix - non-strict mode",
@@ -156,13 +159,13 @@ func TestValidate(t *testing.T) {
 			name:    "timezone content with u- prefix - strict mode",
 			input:   "2025-01-01T00:00:00Z[u-invalid-timezone]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[u-invalid-timezone]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name",
+			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[u-invalid-timezone]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name \"u-invalid-timezone\"",
 		},
 		{
 			name:    "timezone content with x- prefix - strict mode",
 			input:   "2025-01-01T00:00:00Z[x-invalid-timezone]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[x-invalid-timezone]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name",
+			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[x-invalid-timezone]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name \"x-invalid-timezone\"",
 		},
 		{
 			name:    "suffix with invalid characters in key",
@@ -197,7 +200,7 @@ func TestValidate(t *testing.T) {
 			name:    "invalid numeric offset rejected in strict mode",
 			input:   "2025-01-01T00:00:00Z[+24:00]",
 			strict:  true,
-			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[+24:00]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name",
+			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[+24:00]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid timezone name \"+24:00\"",
 		},
 		{
 			name:    "invalid timezone syntax rejected by ABNF",
@@ -241,7 +244,7 @@ func TestValidate(t *testing.T) {
 			name:    "empty timezone brackets",
 			input:   "2025-01-01T00:00:00Z[]",
 			strict:  false,
-			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": invalid IXDTF suffix format",
+			wantErr: "IXDTFE parsing time \"2025-01-01T00:00:00Z[]\" as \"2006-01-02T15:04:05Z07:00*([time-zone-name][tags])\": empty IXDTF suffix bracket \"[]\"",
 		},
 		{
 			name:    "suffix key starting with number",
@@ -275,3 +278,185 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateSuffixBounds verifies that oversized suffixes are rejected by
+// the up-front bound check before the ABNF pattern runs.
+func TestValidateExtensions(t *testing.T) {
+	t.Parallel()
+
+	tags := func(kv ...string) map[string]string {
+		m := make(map[string]string)
+		for i := 0; i < len(kv); i += 2 {
+			m[kv[i]] = kv[i+1]
+		}
+		return m
+	}
+	tests := []struct {
+		name     string
+		tags     map[string]string
+		critical map[string]bool
+		wantKey  string
+		wantErr  error
+	}{
+		{"valid", tags("u-ca", "gregory", "a-key", "x"), nil, "", nil},
+		{"private key", tags("a-key", "x", "x-bad", "y"), nil, "x-bad", ixdtf.ErrPrivateExtension},
+		{"experimental key", tags("_exp", "y", "b-key", "z"), nil, "_exp", ixdtf.ErrExperimentalExtension},
+		{"bad format", tags("Bad", "y", "c-key", "z"), nil, "Bad", ixdtf.ErrInvalidExtension},
+		{"first in sorted order", tags("x-b", "1", "x-a", "2", "x-c", "3"), nil, "x-a", ixdtf.ErrPrivateExtension},
+		{
+			"critical without tag",
+			tags("u-ca", "gregory"),
+			map[string]bool{"u-ca": true, "missing": true},
+			"missing",
+			ixdtf.ErrCriticalExtension,
+		},
+		{"unknown calendar", tags("u-ca", "hoge"), nil, "u-ca", ixdtf.ErrInvalidTagCalendarIdentifier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ext := ixdtf.NewIXDTFExtensions(&ixdtf.NewIXDTFExtensionsArgs{Tags: tt.tags, Critical: tt.critical})
+			err := ixdtf.ValidateExtensions(ext)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ValidateExtensions() unexpected error: %v", err)
+				}
+				return
+			}
+			var tagErr *ixdtf.TagError
+			if !errors.As(err, &tagErr) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateExtensions() error = %v, want a *TagError matching %v", err, tt.wantErr)
+			}
+			if tagErr.Key != tt.wantKey {
+				t.Errorf("TagError.Key = %q, want %q", tagErr.Key, tt.wantKey)
+			}
+			if !strings.Contains(err.Error(), `tag key "`+tt.wantKey+`"`) {
+				t.Errorf("ValidateExtensions() error = %q, want it to name %q", err, tt.wantKey)
+			}
+		})
+	}
+
+	if err := ixdtf.ValidateExtensions(nil); err != nil {
+		t.Errorf("ValidateExtensions(nil) unexpected error: %v", err)
+	}
+}
+
+func TestValidateSuffixBounds(t *testing.T) {
+	t.Parallel()
+	const base = "2025-01-01T00:00:00Z"
+
+	t.Run("many bracket groups fail fast", func(t *testing.T) {
+		t.Parallel()
+		input := base + strings.Repeat("[a=b]", 10*ixdtf.DefaultMaxSuffixElements)
+		start := time.Now()
+		err := ixdtf.Validate(input, false)
+		if !errors.Is(err, ixdtf.ErrTooManyTags) {
+			t.Fatalf("Validate(many groups) error = %v, want ErrTooManyTags", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Validate(many groups) took %v, want fast rejection", elapsed)
+		}
+	})
+
+	t.Run("element count at the bound is accepted", func(t *testing.T) {
+		t.Parallel()
+		input := base + strings.Repeat("[a=b]", 3)
+		if err := ixdtf.Validate(input, false, ixdtf.WithMaxSuffixElements(3)); err != nil {
+			t.Fatalf("Validate(%q) unexpected error: %v", input, err)
+		}
+		input += "[a=b]"
+		if err := ixdtf.Validate(input, false, ixdtf.WithMaxSuffixElements(3)); !errors.Is(err, ixdtf.ErrTooManyTags) {
+			t.Fatalf("Validate(%q) error = %v, want ErrTooManyTags", input, err)
+		}
+	})
+
+	t.Run("suffix length is bounded", func(t *testing.T) {
+		t.Parallel()
+		input := base + "[u-ca=gregory]"
+		if err := ixdtf.Validate(input, false, ixdtf.WithMaxSuffixLength(8)); !errors.Is(err, ixdtf.ErrSuffixTooLong) {
+			t.Fatalf("Validate(%q) error = %v, want ErrSuffixTooLong", input, err)
+		}
+	})
+
+	t.Run("non-positive bounds disable the check", func(t *testing.T) {
+		t.Parallel()
+		input := base + strings.Repeat("[a=b]", 2*ixdtf.DefaultMaxSuffixElements)
+		err := ixdtf.Validate(input, false, ixdtf.WithMaxSuffixElements(0), ixdtf.WithMaxSuffixLength(0))
+		if err != nil {
+			t.Fatalf("Validate(unbounded) unexpected error: %v", err)
+		}
+	})
+}
+
+func TestControlBytesInTags(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct{ name, ctrl string }{
+		{"NUL", "\x00"},
+		{"tab", "\t"},
+		{"escape", "\x1b"},
+		{"DEL", "\x7f"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			for _, input := range []string{
+				"2025-01-01T00:00:00Z[u-ca=greg" + tc.ctrl + "ory]",
+				"2025-01-01T00:00:00Z[a-key" + tc.ctrl + "=value]",
+				"2025-01-01T00:00:00Z[!a-key=" + tc.ctrl + "]",
+			} {
+				for _, strict := range []bool{false, true} {
+					if _, _, err := ixdtf.Parse(input, strict); !errors.Is(err, ixdtf.ErrInvalidExtension) {
+						t.Errorf("Parse(%q, %t) error = %v, want ErrInvalidExtension", input, strict, err)
+					}
+					if err := ixdtf.Validate(input, strict); !errors.Is(err, ixdtf.ErrInvalidExtension) {
+						t.Errorf("Validate(%q, %t) error = %v, want ErrInvalidExtension", input, strict, err)
+					}
+				}
+			}
+			ext := ixdtf.NewIXDTFExtensions(nil)
+			if err := ext.SetTag("a-key", "val"+tc.ctrl+"ue"); !errors.Is(err, ixdtf.ErrInvalidExtension) {
+				t.Errorf("SetTag() error = %v, want ErrInvalidExtension", err)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2025-01-01T00:00:00Z[u-ca=gregory]", ""},
+		{
+			"2025-01-01T00:00:00Z[invalid@key=value]",
+			"2025-01-01T00:00:00Z[invalid@key=value]\n" +
+				"                            ^ unexpected '@' at offset 28",
+		},
+		{
+			"2025-13-01T00:00:00Z",
+			"2025-13-01T00:00:00Z\n" +
+				"     ^ unexpected '1' at offset 5",
+		},
+		{
+			"2025-01-01T00:00:00Z[u-ca=gregory",
+			"2025-01-01T00:00:00Z[u-ca=gregory\n" +
+				"                                 ^ unexpected end of input",
+		},
+		{
+			"2025-01-01T00:00:00Z[é=x]",
+			"2025-01-01T00:00:00Z[é=x]\n" +
+				"                     ^ unexpected 'é' at offset 21",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := ixdtf.Explain(tt.input); got != tt.want {
+				t.Errorf("Explain(%q) =\n%s\nwant\n%s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
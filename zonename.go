@@ -0,0 +1,175 @@
+package ixdtf
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// resolveZoneAnnotationFold is resolveZoneAnnotation for
+// WithCaseInsensitiveTimezone: when name does not resolve as given, it
+// retries with the canonical IANA spelling found by canonicalZoneName.
+func resolveZoneAnnotationFold(name string, loader LocationLoader) (*time.Location, error) {
+	loc, err := resolveZoneAnnotation(name, loader)
+	if err == nil {
+		return loc, nil
+	}
+	if canonical, ok := canonicalZoneName(name, loader); ok {
+		return resolveZoneAnnotation(canonical, loader)
+	}
+	return nil, err
+}
+
+// canonicalZoneName returns the correctly cased IANA name matching name
+// case-insensitively. It first tries the usual capitalization of each word
+// ("america/new_york" becomes "America/New_York"), which covers most zones
+// and works with any loader, then falls back to an index of the system
+// zoneinfo directory for irregular names such as "UTC" or "EST5EDT".
+func canonicalZoneName(name string, loader LocationLoader) (string, bool) {
+	if candidate := titleCaseZoneName(name); candidate != name {
+		if _, err := loadLocationCached(candidate, loader); err == nil {
+			return candidate, true
+		}
+	}
+	canonical, ok := zoneNameIndex()[strings.ToLower(name)]
+	return canonical, ok
+}
+
+// titleCaseZoneName upper-cases the first letter of each word of name and
+// lower-cases the rest, where words are separated by '/', '_', or '-'.
+func titleCaseZoneName(name string) string {
+	b := []byte(name)
+	start := true
+	for i, c := range b {
+		switch {
+		case c == '/' || c == '_' || c == '-':
+			start = true
+			continue
+		case start && 'a' <= c && c <= 'z':
+			b[i] = c - 'a' + 'A'
+		case !start && 'A' <= c && c <= 'Z':
+			b[i] = c - 'A' + 'a'
+		}
+		start = false
+	}
+	return string(b)
+}
+
+// zoneinfoDirs are the directories the time package searches for zone files
+// on Unix systems, in order; $ZONEINFO is consulted first.
+//
+//nolint:gochecknoglobals // Read-only search path.
+var zoneinfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// zoneNameIndex maps lower-cased zone names to their canonical spelling,
+// built once from the first zoneinfo directory that exists. It is empty when
+// no directory is found, e.g. when only the embedded time/tzdata is present.
+//
+//nolint:gochecknoglobals // Built once per process; the zoneinfo tree does not change while running.
+var zoneNameIndex = sync.OnceValue(func() map[string]string {
+	dirs := zoneinfoDirs
+	if dir := os.Getenv("ZONEINFO"); dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, dir := range dirs {
+		if index := indexZoneinfoDir(os.DirFS(dir)); len(index) > 0 {
+			return index
+		}
+	}
+	return map[string]string{}
+})
+
+// indexZoneinfoDir lists the zone files in fsys, skipping the "posix" and
+// "right" variant trees and data files such as "zone.tab".
+func indexZoneinfoDir(fsys fs.FS) map[string]string {
+	index := make(map[string]string)
+	_ = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // Unreadable entries are skipped.
+		}
+		if d.IsDir() {
+			if p == "posix" || p == "right" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		base := path.Base(p)
+		if strings.Contains(base, ".") || base == "localtime" || base == "posixrules" || base == "leapseconds" {
+			return nil
+		}
+		index[strings.ToLower(p)] = p
+		return nil
+	})
+	return index
+}
+
+// longestZoneName is the byte length of the longest name in zoneNameIndex.
+//
+//nolint:gochecknoglobals // Derived once from zoneNameIndex.
+var longestZoneName = sync.OnceValue(func() int {
+	longest := 0
+	for key := range zoneNameIndex() {
+		longest = max(longest, len(key))
+	}
+	return longest
+})
+
+// maxSuggestionDistance bounds how far, in case-insensitive edits, a
+// suggested zone name may be from the name written.
+const maxSuggestionDistance = 3
+
+// suggestZoneName returns the zone in the system zoneinfo index closest to
+// name by case-insensitive edit distance, or "" when none is within
+// maxSuggestionDistance. Ties go to the lexically smallest name.
+//
+// The length of two strings differs by no more than their edit distance, so
+// names too long to be near any indexed zone return at once and candidates
+// whose length is out of range are skipped without computing a distance;
+// this keeps a long invalid annotation from costing a quadratic comparison
+// against every zone.
+func suggestZoneName(name string) string {
+	if len(name) > longestZoneName()+maxSuggestionDistance {
+		return ""
+	}
+	lower := strings.ToLower(name)
+	best, bestDistance := "", maxSuggestionDistance+1
+	for key, canonical := range zoneNameIndex() {
+		if diff := len(key) - len(lower); diff > maxSuggestionDistance || -diff > maxSuggestionDistance {
+			continue
+		}
+		d := editDistance(lower, key)
+		if d < bestDistance || (d == bestDistance && canonical < best) {
+			best, bestDistance = canonical, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,85 @@
+package ixdtf
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTitleCaseZoneName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"asia/tokyo":                     "Asia/Tokyo",
+		"AMERICA/NEW_YORK":               "America/New_York",
+		"america/port-au-prince":         "America/Port-Au-Prince",
+		"america/north_dakota/new_salem": "America/North_Dakota/New_Salem",
+		"etc/gmt+5":                      "Etc/Gmt+5",
+	}
+	for in, want := range tests {
+		if got := titleCaseZoneName(in); got != want {
+			t.Errorf("titleCaseZoneName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestIndexZoneinfoDir(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"UTC":                    {},
+		"EST5EDT":                {},
+		"America/Port-au-Prince": {},
+		"Etc/GMT+5":              {},
+		"posix/UTC":              {},
+		"right/UTC":              {},
+		"zone.tab":               {},
+		"tzdata.zi":              {},
+		"posixrules":             {},
+		"localtime":              {},
+	}
+	want := map[string]string{
+		"utc":                    "UTC",
+		"est5edt":                "EST5EDT",
+		"america/port-au-prince": "America/Port-au-Prince",
+		"etc/gmt+5":              "Etc/GMT+5",
+	}
+	if got := indexZoneinfoDir(fsys); !maps.Equal(got, want) {
+		t.Errorf("indexZoneinfoDir() = %v, want %v", got, want)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"asia/tokio", "asia/tokyo", 1},
+		{"americ/new_york", "america/new_york", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// TestSuggestZoneNameLongName does not call t.Parallel because
+// testing.AllocsPerRun refuses to run in a parallel test.
+func TestSuggestZoneNameLongName(t *testing.T) {
+	// A name far longer than any zone must return without lower-casing it
+	// or computing a distance against the index.
+	long := strings.Repeat("Asia/Tokyo", 6000)
+	if got := suggestZoneName(long); got != "" {
+		t.Errorf("suggestZoneName(<%d bytes>) = %q, want \"\"", len(long), got)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { suggestZoneName(long) }); allocs != 0 {
+		t.Errorf("suggestZoneName(<%d bytes>) allocated %v times, want 0", len(long), allocs)
+	}
+}